@@ -101,6 +101,9 @@ func TestBeatConfig(t *testing.T) {
 						},
 					},
 				},
+				"error": map[string]interface{}{
+					"skip_schema_validation": true,
+				},
 			},
 			beaterConf: &Config{
 				Host:            "localhost:3000",
@@ -140,6 +143,7 @@ func TestBeatConfig(t *testing.T) {
 						},
 					},
 				},
+				ErrorConfig: &errorConfig{SkipSchemaValidation: true},
 			},
 			msg: "Given config overwrites default",
 		},
@@ -211,6 +215,7 @@ func TestBeatConfig(t *testing.T) {
 						},
 					},
 				},
+				ErrorConfig: &errorConfig{},
 			},
 			msg: "Given config merged with default",
 		},
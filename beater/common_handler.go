@@ -141,10 +141,8 @@ var (
 	}
 )
 
-type reqLoggerKey struct{}
-
 func ContextWithReqLogger(ctx context.Context, rl *logp.Logger) context.Context {
-	return context.WithValue(ctx, reqLoggerKey{}, rl)
+	return utility.ContextWithRequestLogger(ctx, rl)
 }
 
 func logHandler(h http.Handler) http.Handler {
@@ -157,16 +155,18 @@ func logHandler(h http.Handler) http.Handler {
 			sendStatus(w, r, internalErrorResponse(err))
 		}
 
-		reqLogger := logger.With(
+		fields := []interface{}{
 			"request_id", reqID,
 			"method", r.Method,
 			"URL", r.URL,
 			"content_length", r.ContentLength,
 			"remote_address", utility.RemoteAddr(r),
-			"user-agent", r.Header.Get("User-Agent"))
+			"user-agent", r.Header.Get("User-Agent")}
+		reqLogger := logger.With(fields...)
 
 		lw := utility.NewRecordingResponseWriter(w)
-		h.ServeHTTP(lw, r.WithContext(ContextWithReqLogger(r.Context(), reqLogger)))
+		ctx := utility.ContextWithRequestLogFields(r.Context(), fields)
+		h.ServeHTTP(lw, r.WithContext(ContextWithReqLogger(ctx, reqLogger)))
 
 		if lw.Code <= 399 {
 			reqLogger.Infow("handled request", []interface{}{"response_code", lw.Code}...)
@@ -295,8 +295,8 @@ func sendStatus(w http.ResponseWriter, r *http.Request, res serverResponse) {
 // requestLogger is a convenience function to retrieve the logger that was
 // added to the request context by handler `logHandler``
 func requestLogger(r *http.Request) *logp.Logger {
-	logger, ok := r.Context().Value(reqLoggerKey{}).(*logp.Logger)
-	if !ok {
+	logger := utility.RequestLogger(r.Context())
+	if logger == nil {
 		logger = logp.NewLogger("request")
 	}
 	return logger
@@ -51,6 +51,7 @@ type Config struct {
 	RumConfig           *rumConfig             `config:"rum"`
 	Register            *registerConfig        `config:"register"`
 	Mode                Mode                   `config:"mode"`
+	ErrorConfig         *errorConfig           `config:"error"`
 }
 
 type ExpvarConfig struct {
@@ -69,6 +70,10 @@ type rumConfig struct {
 	beatVersion string
 }
 
+type errorConfig struct {
+	SkipSchemaValidation bool `config:"skip_schema_validation"`
+}
+
 type eventRate struct {
 	Limit   int `config:"limit"`
 	LruSize int `config:"lru_size"`
@@ -143,6 +148,9 @@ func newConfig(version string, ucfg *common.Config) (*Config, error) {
 			return nil, errors.New(fmt.Sprintf("Invalid regex for `exclude_from_grouping`: %v", err.Error()))
 		}
 	}
+	if err := modelConfig(c).Validate(); err != nil {
+		return nil, errors.Wrap(err, "Invalid error model configuration")
+	}
 	return c, nil
 }
 
@@ -197,6 +205,10 @@ func (c *rumConfig) memoizedSmapMapper() (sourcemap.Mapper, error) {
 	return c.SourceMapping.mapper, nil
 }
 
+func (c *errorConfig) skipSchemaValidation() bool {
+	return c != nil && c.SkipSchemaValidation
+}
+
 func (c *InstrumentationConfig) isEnabled() bool {
 	// self instrumentation is disabled by default.
 	return c != nil && c.Enabled != nil && *c.Enabled
@@ -264,6 +276,7 @@ func defaultConfig(beatVersion string) *Config {
 						filepath.Join("ingest", "pipeline", "definition.json")),
 				}},
 		},
-		Mode: ModeProduction,
+		Mode:        ModeProduction,
+		ErrorConfig: &errorConfig{},
 	}
 }
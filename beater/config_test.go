@@ -25,6 +25,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/elastic/apm-server/model"
 	"github.com/elastic/beats/libbeat/common"
 	"github.com/elastic/beats/libbeat/outputs"
 	"github.com/elastic/go-ucfg/yaml"
@@ -73,6 +74,9 @@ func TestConfig(t *testing.T) {
 							path: "tmp",
 						}
 					}
+				},
+				"error": {
+					"skip_schema_validation": true,
 				}
       }`),
 			expectedConfig: Config{
@@ -106,6 +110,7 @@ func TestConfig(t *testing.T) {
 						},
 					},
 				},
+				ErrorConfig: &errorConfig{SkipSchemaValidation: true},
 			},
 		},
 		{
@@ -230,6 +235,18 @@ func TestDefaultRum(t *testing.T) {
 	assert.Equal(t, c.RumConfig, defaultRum("7.0.0"))
 }
 
+func TestModelConfig(t *testing.T) {
+	c := defaultConfig("7.0.0")
+	assert.Equal(t, model.Config{}, modelConfig(c))
+
+	c.Mode = ModeExperimental
+	c.ErrorConfig.SkipSchemaValidation = true
+	assert.Equal(t, model.Config{Experimental: true, SkipErrorSchemaValidation: true}, modelConfig(c))
+
+	c.ErrorConfig = nil
+	assert.False(t, modelConfig(c).SkipErrorSchemaValidation)
+}
+
 func TestMemoizedSmapMapper(t *testing.T) {
 	truthy := true
 	esConfig, err := common.NewConfigFrom(map[string]interface{}{
@@ -59,6 +59,12 @@ func adjustMissingTimestamp(event *beat.Event) {
 	}
 }
 
+func adjustIngestedTimestamp(event *beat.Event) {
+	if ok, _ := event.Fields.HasKey("event.ingested"); ok {
+		event.Fields.Put("event.ingested", timestampOverride)
+	}
+}
+
 // testPublish exercises the publishing pipeline, from apm-server intake to beat publishing.
 // It posts a payload to a running APM server via the intake API and gathers the resulting documents that would
 // normally be published to Elasticsearch.
@@ -80,6 +86,7 @@ func testPublish(t *testing.T, apm *beater, events <-chan beat.Event, url string
 			continue
 		}
 		adjustMissingTimestamp(&e)
+		adjustIngestedTimestamp(&e)
 		doc, err := enc.Encode("apm-test", &e)
 		require.NoError(t, err)
 		var d map[string]interface{}
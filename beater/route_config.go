@@ -167,6 +167,15 @@ func (r *assetRoute) Handler(p asset.Processor, beaterConfig *Config, report pub
 	return r.wrappingHandler(beaterConfig, handler.Handle(beaterConfig, report))
 }
 
+// modelConfig returns the configuration for decoding and transforming events
+// received by the intake routes.
+func modelConfig(c *Config) model.Config {
+	return model.Config{
+		Experimental:              c.Mode == ModeExperimental,
+		SkipErrorSchemaValidation: c.ErrorConfig.skipSchemaValidation(),
+	}
+}
+
 type intakeRoute struct {
 	routeType
 }
@@ -180,7 +189,7 @@ func (r intakeRoute) Handler(url string, c *Config, report publish.Reporter) htt
 		requestDecoder: reqDecoder,
 		streamProcessor: &stream.Processor{
 			Tconfig:      r.transformConfig(c),
-			Mconfig:      model.Config{Experimental: c.Mode == ModeExperimental},
+			Mconfig:      modelConfig(c),
 			MaxEventSize: c.MaxEventSize,
 		},
 	}
@@ -32,5 +32,5 @@ func init() {
 // AssetFieldsYml returns asset data.
 // This is the base64 encoded gzipped contents of fields.yml.
 func AssetFieldsYml() string {
-	return "eJzsvWtzHDeyKPjdvwLLibuU5jabpETJMm/MPcuRZJthS+IxqeOZc+YEG12F7oZZBZQBFFvtjf3vG8jEqx79ItmyFJf+MCNWVwGJRCIzkc+/kF/Pfnl//v6H/4u8kURIQ1jODTEzrsmEF4zkXLHMFIsB4YbMqSZTJpiihuVkvCBmxsjb15ekUvI3lpnBN38hY6pZTqSA57dMaS4FOR4eDY+G3/yFXBSMakZuueaGzIyp9Onh4ZSbWT0eZrI8ZAXVhmeHLNPESKLr6ZRpQ7IZFVMGj+ywE86KXA+/+eaA3LDFKWGZ/oYQw03BTu0L3xCSM50pXhkuBTwi37tviPv69BtCDoigJTsl+/+P4SXThpbV/jeEEFKwW1ackkwqBn8r9nvNFctPiVE1PjKLip2SnBr8szHf/htq2KEdk8xnTACa2C0ThkjFp1xY9A2/ge8IubK45hpeysN37JNRNLNonihZxhEGdmKe0aJYEMUqxTQThospTORGjNP1bpiWtcpYmP98knyAv5EZ1URID21BAnoGSBq3tKgZAB2AqWRVF3YaN6ybbMKVNvB9CyzFMsZvI1QVr1jBRYTrF4dz3C8ykYrQosAR9BD3iX2iZWU3ff/Z0fHLg6MXB8+eXx29Oj16cfr8ZPjqxfP/3E+2uaBjVujeDcbdlGNLxfAA/3mNz2/YYi5V3rPRr2ttZGlfOEScVJQrHdbwmgoyZqS2R8JIQvOclMxQwsVEqpLaQexztyZyOZN1kcMxzKQwlAsimLZbh+AA+dr/zooC90ATqhjRRlpEUe0hDQC89Qga5TK7YWpEqMjJ6OaVHjl0tDDpvqNVVfCM4ionUh6MqXI/MXF7ag98Xmf25wS/JdOaTtkKBBv2yfRg8XupSCGnDg9ADm4st/kOG/iTfdP9PCCyMrzkfwSys2Ryy9ncHgkuCIW37QOmAlLsdNqoOjO1RVshp5rMuZnJ2hAqItU3YBgQaWZMOe5BMtzZTIqMGiYSwjfSAlESSmZ1ScWBYjSn44IRXZclVQsikwOXnsKyLgyvirB2Tdgnru2Jn7FFnLAcc8FywoWRRIrwdvtE/MiKQpJfpSryZIsMna46ACmh86mQil3Tsbxlp+T46NlJd+d+5trY9bjvdKB0Q6eE0WzmV9k8rP+1F+lnb0D2mLh9tvff6VGlUyaQUhxXPwsPpkrW1Sl51kNHVzOGX4ZdcqfI8VZK6NhuMnLBiZnbw2P5p7HybeJpXywszqk9hEVhj92A5MzgP6QicqyZurXbg+QqLZnNpN0pqYihN0yTklFdK1baF9yw4bX24dSEi6yoc0b+zqhlA7BWTUq6ILTQkqha2K/dvEoPQaDBQod/dUt1Q+qZ5ZFjFtkxULaFn/JCe9pDJKlaCHtOJCLIwpasz5/3+YyplHnPaFUxS4F2sXBSw1KBsVsECEeNEymNkMbuuV/sKTnH6TKrCMgJLhrOrT2Igwjf0JICcYrImFEzTM7v2cU7UEmc4GwuyO04rapDuxSesSGJtJEy31wyjzrguqBnED5BauGaWPFKzEzJejojv9estuPrhTas1KTgN4z8RCc3dEB+YTlH+qiUzJjWXEz9prjXdZ3NLJP+WU61oXpGcB3kEtDtUIYHEYgcURi0lXg6WDVjJVO0uOae67jzzD4ZJvLIizqneum5bp+lt34OwnN7RCacKSQfrh0in/AJcCBgU/ppoGuv01hJpkrQDrwCRzMltRX+2lBlz9O4NmSE283zEeyH3QmHjIRpvKInkxdHR5MGItrLD+zsXkv/KPjvVr3Zft1B3FoSRcKG7+Yg18eMABnzfOny8sby7P/uYoFOa4HzlXKEzg5qQvEtZIcogqb8loHaQoX7DN92P89YUU3qwh4ie6jdCsPAZi7J9+5AEy60oSJzakyLH2k7MTAlSyROnJIoTllFFXUqiFu+JoKxHO8f8xnPZt2pwsnOZGkns+p1su7ziVV8PeeBpSJL8o/kxDBBCjYxhJWVWXS3ciJlYxftRu1iF68W1Yrt89zOTkC0oQtNaDG3/xdwa1VBPfOkidvqtHH81krzYUSNCDw7YDW+iyTuphiz+AqIMD5pbHzcsTYBNDa/pNnMXgm6KE7H8Xh2l80doPo/3DW2iewWTC/tHfdAZc8SNSYreEuPeR2frFBkztyXluByNgGFj+LOccENp0YCU6JEMDOX6sZqOoKBQmVPnYcNFRTFplTlILisXJJCD5L3UWiNOd70ubSa76SQc3tDszpdQ22+en3hRsVTEcHswGYf2NcTyICLaCaCumLfufzne1LR7IaZJ/rpEGZBTbtS0shMFp2p8EZrxUpjUq9nKbiuM3sp8pqAx5JRVGgKwAzJpSxZkM21Rh3HMFWSPX9Nl2ovavWKTZhqgCJaC9SoZrifnQ6KOztmQQcDHTRBAIJALFhi6rc5TpHCj9q0IyI/gT05ta4tQtyoUfnjwoL3Wy1wA0AXRO3OG1FIz2gRwUKazpiWq+OGHcAh89fXcOnF8Q79RMFMAcwa5YS9CWtWUmF4Blo6+2ScSGGfUFkYIAf/JrB2L1iMJLfcrpf/waJmb1fKFGj7mpuauv04n5CFrFWYY0KLwlMfF16uGTaVajGwr3qOqA0vCsKE1W0d4aJtxHLNnGlj6cPi1CJswosiKF20qpSsFKeGFYsttDqa54ppvSuFDsgdVXhHXG5Cx3wDnynHfFrLWhcLJGf4JnDsuUWLliUDmxAp7A2QCnJ+MSCU5LK0GyAVoaQW/BPR0tLJkJB/Rsw6GQFGi6gWzBhRdO5h8oQ/GroHI0RZU8QJewOIEiyv0WiBV9DRkFcjC8poiGCN7DWuYiJ3OgYqCFJEIOA+4XbM78p4YZheI1MKGXR9vFo0P2vsw9/tD3itCJY9tx/23mz5AV4H2vLl+NVJAzBc1A6knTu/OP6wMeeUyWHGzeJ6R5rpa24WMFVn9e+kMIrRoguOFIYLJsyuYHqfaMlhsg5876UyM3JWMsUz2gNkLYxaXHMtrzOZ7wR1OAU5v/xA7BQdCF+fLQVrV7vpQOrd0NdU0LyLqUJmqU6/DJwpk9eV5IEvNa1SUky5qXPk1QU18EcHgv3/l+wVUuydkoNvnw9fHp+8en40IHsFNXun5OTF8MXRi++OX5H/b78DZBdfD8emP2qmDjwvTn5Cdc+jZ0Cc8o0SWE7IVFFRF1Rxs0iZ6oJklrmDzpEwz9eeZ4arDVI4VyhNMyYMU07zmhRSKiLqcszUAFT5GY96jQ6DIngFqWYLze0/vGkt88daJyC8lyZxH4DhkAtCayNLYOFTJv1quxeAsdRGioM86+yNYlMuxS5P2i8ww6qDdvDvr5fBtaOj5mDqPWn/XrMxayKKV2tgCC80ifP8IghozxFBWKSUhVYAKZiVvcGmfX5xe2IfnF/cvoyKR0vWljTbAW7enb1eBnU6Oaq0W4j6xiQX+PWdBPuzJhxSmbsCIZVZtcRaMzVkJeXFjriXZV4EJvAY7wFgUhdFzzl4UCD2NbHTwLTAsugt5QUdF93jcVaMmTLkLRfaMKdQNeAFrX24M0tr19o4cZZ1mDgYROCWeFgV1FgdswevCOcOEZtqQjhZF4gZ1bOdiUbElJ2H2HnsucqkUszeSxtm/QneQOyLVqYIKRapkxDV9IRpfdTMmSxHsAqe480B/rCrGwVXUibFBPeKFo05ra6RURFvzMS7fltczs2wA073ocV06zZpBQYIMHSh2pF0upxZxoRqBrh5uOgCkhxJCkeyYUeTNU4ZzGj+wXIrGkZ8ECSP3DNhGIqAaWiiaHADRwcX3obROuwvdWAjJksdWhPyjhnFMzQ069SQTQV5+/oZmrEthUyYyWZMg5aVjE640c6HGIG01NV0fTd8mFwHA2kTBDeuqoVzTipWShPMqUTWRvOcJTO1IUOYKHHeM78gv+kifuo0xKaXHgeNA4Gb0E3uBaEdlusIqkPYNvaSDO4vu+PM+1cRQTgXuEfVlAr+Bx56ngeXtztlC5LzyYSp1GYCejAHRy+heDwPDBNUGMLELVdSlE0lKtLW2a+XYXKeD8gPUk4LhvRPPvzyAznP0SkNJtPOge9qzi9fvvz2229fvXr13XffNdGJEpIX9n7/RzSLPDRWz5J5iJ3HYgVtMUDTcFTiIeowh1ofMKrNwXFLpXWehN2Rw7n3IJ2/8dwLYPWHsA0oPzh+9vzkxctvX313RMdZziZH/RDvUGQHmFNfXxfqRAGHh12X1YNB9M7zgcR7tRKN5tmwZDmvy6aWrOQtz0OQwi5VHeQAfsKhP5xpABad6wGhf9SKDcg0qwbhIEtFcj7lhhYyY1R0Jd1cN5aFt8QdLcpdEu943FJxjIzeYd+L5MbDFc6t8GLTgeE8C534uCRkp2IZn3B/RwxQoHne+aCclV5O0kGSYEummZ93xooqUSBBXmH4ahhaO0koFhZBhpdsCwG1Ex3PKcFx8TxvnmFe0ulOeUp6NmCyYBpFgOZUk3HNC2PFeQ9ohk53BFmkLAcXnTYBSCJAV8+eRIKuiAVtM1uY1IVVNubd4W7ENUfjT+AmSLK7Yic4OimpoFOrvQE/CXTQ4SQYgZqwkcSLljKSN63HK1hJ8upqdytqz8nbYE1Fk89hMxKzZ8zEw7rOt4rcx/lWv0TfX8N1uZEDMKqxGLz9QA7AMCw4Av/PdgCmm+KNhS5Kv3WIPpsXMD0Gj67AR1fgw4D06ArcHGePrsBHV+DX5ApMhNjX5g9sgE527BTcQtjvxDO4dLGP7sFH9+Cje5A8uge/Nvcg5n+3MsBXGQ7eMUMP0t3xpkWXYY5TbnJxX5d00JM5fr+0rCSrHnQvF9ErYTGaGDkkI5bpoXtphEk8HoxI4eCxs0RZ1tpgKhMchqITz03Ir/am/XvN1AIi1DGHK5ARFznPmCYHB+5GXdKFBwiS+As+nZmizzGWrAa+d3UHLGiFFZxcGDZVLm6c5r9ZUL3IzGaspC38k0Zyre4qi1CIIKUcpWTDiv02PFidZxqtyBkkJbkQdxwQzhEVC3LDRbRYfMQUgxLTovA9sFxjRqVFXsHQDWvR7LNLgUdlVDMdUzH9smDvudGsmETvKxU4+hbmpx2px4BMGNxfEdBMyByATUV0h9byHunZA0Gav74cjJDD3rtYn42d0thtKwfo7e2Gucy4v31eEp/O0O8oKaRXAtGhonjWoJVAkmeQHt9MMrLk43mKJSi7ZUn6MFj+ZriPNGYDeyb9c0zjB8biU5sht4aXzF5WvffJPrUDhTFiRrScJItw4/mhqM+wJZBE6gMtXPhETIlC3Z2MGWY+ORXcjUm9qdZIQlOVeIDGy568qjEzc8bsTD5/QuQuRiL4IXEyl5KEOdJZIa2QJ2d+J9ajGy9LbshSKmZv3GBOKmBEzFeBP9NEcwCoH9HJa27YmKrdwHpKLRHlJSulWhDL5CAfxg2XJ4iPBHdbF4Ip9PDzmAvvXtZWCWI5ZsJvE+yxgSnozkEeODrJaIUlIVwWZNMx4JJig7HDZZ/FA8iTSi9Dcg4uSdi9qF3MqCAjfMFnHY1ihmXYCHvWR4CQA5rnowEZOZI/AJJn8GjCC3aQKWYJbYSpOr4uSxgxJGB7inMr43aeEiw7XSFpla6DimptkXmA2VhNceFA38V2vMXD4GZoIz8IuRmfzlz6WT8PBA4JAnTS2ZUwJuwOZLu1NgcJYjTwe6qZ0C4NLBqqaAAzwBVH9toR9ZmBv1JlDzfUP5jUEHMWVB85sarQgMwZqQoKZgEXb0BoGLJwxTZolrHKQA60C0FAmeZVpwGpsMpSrRl6pTJa99vOYKfBfxdZQ9hkpKw1exwKILX30RE5DtKJYuuvjmR5EhQMCmtWjALN+lRzzFVdYE5fp2SQIxJUIO1R5ZatZ872Eos8hcy/5FHcVgdrGDNw1J6aTKFWTJtVnAtSSm2SXEQwoFoimstYT0mjO23MerRkPNL+zyx6qbJmVaGMFhm4JJ11p6CLIKsAT07SuUJQoMI7oRMDVRqiA7YFPvXVVJQ2XuqynPBWyr+HpJSCx0Rckgyxvw+arN8x+6cPATOS3DBWkbpCYoWP0mpUTaxCCjpA2sSjZZmo5mW0GKQ7G/2DPbftnBqq2Tqz2p04WWoPcdO0MvQzKexRRnv+yL0zIk8sZ9fMkEMnjjUzTy09e8s4VpawygPR9TiCD9efUuZ1wTSwusaxS/kkagZ2B2tlaa1Y+CJSXMRJ0ws/kkj8Caexm+qghZe7LEYbapoxTnmtNvHr9PhUW19yUdXm2v8oqJCaZTJml7diBdzHDYFgl5t82CwEgWcaJC4sHv9mVutTjNwIORdpObRIZ6b/3PpDCbMLvH3j6ElgUbg1iE0sisvYbwS1w3nbTBcGtfsYnluRdZs6jyxfLqiVPlgaqBVxtEOj3o9Uz8iTiqkZrTQUCILCORMupkxVigvz1O6nonPH9Y20GwDC0ciwgJyVUmij7PLhxgN2BW4WPSZ3H7LZ96+zv79+89kuredv7GpCPEuikLZg7q0dc8M3IqA7q8x2/P5SZk4KT/ktRDy3lbO5U6LaMXoJSXqajeLJl2dzl7nEWrdC12vp0/B0FMccWdbErCZNC6rK0ZepogGQTTMFcN5dSyzH39G/u7JkDpYKSu9BjTeT0doSTKpQC6u78HKhf2/GeHhlaxdL/4XOwbITiv7JCfisVaCmj07JWcFLlqihQlo5k7NPDHl+LrPrJHg459pSSo4SG1wEoBAyqrIZyyPBjmtDeCjDpKwoZrdeGx1do7Y06mLyklXk+Dty9Or02cvT4yMM+X399vvTo//7L8fPTv7XJctquwD8i5iZVdrxVqDw2fHQvXp85P4RT6ZUJdF1ZlXDSV2gIlFVLPcf4P9rlf3t+AjKwB6TXJu/PRseD58Nn+nK/O342fOmo1PWJpO7i6uw7MtNsYyDNYqixhu/vYZkaCWKh1k3ZWxj5KTUkS87E60t+KLjTg6FrkDnhPKiVqyXJ4URN+JNm/OkMO7mvAlhbuyd4vrmWieHctkxnRSS9hpSf+H6hsAIWE2PS0ucTbXtCRtOh0Q7wiVaFgCifhqNKR81c9cfcI3CBcRd1lBfmzHVjpcNsF8LqcoN6G/pIvbfg+WF/8FyGHbNggbBOGZ16klYxJHdy+Ojo57KbCXlAqNlnG9yIWvYsxLDKakAO6KrLgTXXao1nwqdAKSbN0A7xJxixrJmlnpEXAZizXl/aFH42kktxVWzW5aEHm0bqXDpPm/Z2cLe+eFbsv7XGUZBRZXPX6PjF47sS0YFMNFbppLrdlDPLQ7B32IZ8n406dSV1zcS6xlce+kNI2AXdVNx5pMIhebagK0Y0eZda62DtP9tC4f2VnBv9R/vFmsvAM6kmF4BGkzLXgWiaWbJHcDeYHaYNLafSNR4z0qKnDaWtL+vo2kgrfFJnCx2PgkHc1NJLRSj+cJxmJxNaF0YcrnQVtZHe0PCaM7RugGQ0gIz8eZcp3aLs8h7w6Q4JRDKKZgShRRg0j9/4ybfe1srWbHDs1IbpnJa7j1Njut4rNgtehn865dXe0/BfSHIjz+elmUkbk4L/9bB0YvTo6O9p61ju6sqhb8wJBeQNk6prtFFFtbiqsLTWwn5lCGXIFb+hlgNq4YO0yrBE+70YOdY+97/vbK0HtS1bzlhiGamex8B/5YmY8sVmuZQ5yeyv4Lr3Hs3wBYCbDGWzbPTufrdXnejWsuMx/K8oJH5unqNYm96YBnzoTOzeL6B3hnYUKuJSM1cRW608MOU514vJe/QLGfR+l/fn7/7b1+9W0cnk8vIhQJ84IVGxcZrEd1cCjqZMDSF2tdb6/FUk5S9d5ajbXzSG6auLOOBP1NfeB5ALJmhGM8K/owW+8qZXf6OmNcbGHxJlhqmTxctTQTm7gaWPBw/hV0Os7TVi5CoUcg5YVQvLIiGAQmNF4jQ8HFPmEXlZHuIet1ZeNyF4lBUHYPhLOv84fzN0+WIjTS3a1jSjNsuHFx0Qi4eMOlX5qzZHcID4f1ZKZ8iTdvCzhJ/LVAJPiwoMjO0aBWI7ChHJ8cvmzA+LGNwxiPQcEqZ8wlvMwc5FztLNEbpYCfYB+uI6mbxVdTsyrx6Qc3MK7VdGtX8j03wvEyTh6XZMexOQzoUeRJsItLeXWiee91tZMeCYDXwa4+ettRLqqbMXO8QFVcwAyAbNA69KAsubloRyjtMjAd0gV0U/D8DknMFSoaDpIWRemcs9crFXQI3/QjcVMWrdhJK9eSyxWqRkNPYpymTqYL2g/tzhX72A5NpZF1Glb2kxbonNFp/fU5IWuKFilRHajbZSdJIGoqeU8pypngwpxmWzcAMH8v2W8jOL5JAF/QoqgNdV1XBg2txI+Xmy8mc++Kz5r7AjLkvLFvui8+Ue8yS+zKz5L7EDLkvIDuue1nw8is8WC7BrkJqThK4WzJnVY2R4vCOiwCH5gesYLc0HE6nlSUe37uUHPmi0pA+d+5RiE+QuhF//aP/e6WZyBfGaZiJXGV8ksmyqg3G+roqTqGr0+tLDG71rZn6DZZpV6ZoVsEeTLFATzPS3wdKg1oIakpvhG8a22vXCngNwbxuxBlV+ZwqNiC3XJmaFr4Akx6QN1CpI6mCA0Yo8lM9ZkowAy16crZVfQuVzbhhWeK/etDMpspHtvlmCsl8nXP+6dXL65fNMgqP1QweqxlsD9JjNYPNcfaopz1WM9h9NQMrP3cEyf6Pbuy0amEaMmKSdnfe5zp3bmky8pCNrO5Q2vOrmKkVlmjtFEHcX6nVPWibO9Rz0sJKZzrg0YcvuZ4tmDE8ABe586YH/dWquFxMIRjBRY+vLG6KmrKLP0aXoMXsCFrkAabaWLhbpQrQgHjVX3FgNxUmfnRb2T/nrujz/UraBGOaS1IHqkwoMqHEj1C0CwM7HJOEoK7fa1qAaTyM6Up9YQkFzJmzADjrXEw1ghRu2GttJYkiOct4DtmsVncFMoqMXdr3Wxsv9XBCS14sdiSaPlwSHJ888bY+xfIZNQOSszGnYkAmirGxzgdkzkUu59H9H6vbwZsduOtiV8U0OjqvK2YBWr73+fhUcZ+G26+C0szi4J38jd6y9gpurMr/2daAswWw4c6l6Jxoo/qKk54MT4ZHB8fHzw5cElcb+h0qNEvw7yOVE+wvQ/g/2tD6a/PngtjP5+je6kZSD0g9roWpV9E6VXPeofXeUgi7A35TGjk+Gh6fDI8b0O4q2MW35Gyx3++lchW7fRVh1xfWeR4a9dHtENBYeBQqH4+gwPttOUgUYAiyTnTdcFkfpG1Xk9rgqccjyuowYp/M7ilM8lgeqEldj+WBHssDPZYH+rLLA82MaVjxf7y6uoC/t+kdYj8K4bBDX8yFjGpVjHxgKsPA6aSxJQCpCg+va0y7uT3ffzCW+WLYU4l2XUDG2mq0l434jCaYBGZto/fVq2+Xg+iCaXZ0hq/cdQQ3YyWUP7KikGQuVZH3Q7sDXF5JQ4tWxEsLo08ssHDYZ4xaPaCrXB2fPO9HcMnMTO4sp6+BUpyqla2MRI5ZAFDbZczS9AAjSSHnTEGCtmWhvmDUkFwylxMrs7r0cV5hbO3qq+yd+7B6q+W9fX251zWPTZkZkAoKvVS16UUTtGlWOwvY+sUNH7NnUsx1dtPyHn16eDgu5HTong4zWR62YNeVFJp99nOO02560FMgP+9JXwXn8qPu4f3cZ91Be7fD7oDWhppa95h6t4rBa6IPx+w37p4cNT1iu73NAVzLrsfHw7TZiK8D5YT3z+7PtbIbzUu0UX5HQsZmmoSziRCGxe/iuvjBJzVZqILDw1Xw6uQkYhH/RkrznCoxGpARFDOz/+A96Z9MqcZydplG65PTGilbdjE+rZa2SxLAKU/eSNTfCdZOKrhBT7shNZRuCRpqRVWjTuE5mjgVjWUCR25Yr6MhVaTGUGg57wu72BHT/Du/F26UNO2zlfXpFjvoLMin9YYxZ/SWhTQjbTcVw44zX+cQownRCMBEJrFfgSKCzUnBBdPQ0O02uZDYq0zBqIActSbI981KJlq6pOP9fRD5VqynduCxN3aBYnDv5GTwtIFP4t3Cnf1gOMfEmJQbvE8erSmm59NqmiEdaDopy1o4/GMEsLxlynOQGD9CcBeS9BwXkqHTBkP+jTsFgPjRWzU42glDvoDPNiEYFTbH2GFSyRne0qb8lgkMxk1ndRyuUtLITBbNEkJUjblRVEUrP3Hpqi51DEoFajwUJc+U9ClLA6BAWmgJky3w5MeX9c2iYtFyxrPfB2RCMzaW8mZAzJwbgw4Krsk8rRRkWU0s3xSLb5JbJvKkyhFER2NDwxBJbEVsHiKHQxkEPAWHudWxzy8wXFoPoLC3HpBkzDlXPkPwC9TCKW82Y3voFin7qF2hVmUUFRp0btiRsbTnhivm6qo1cvZHrmIUfOlS6dNy5/65L98zICN/WN1PKLt43Aldl10EPH/5qoEAx0HM4np3zSjP0GoFJTgheQyYdlJL/vwCK0A6aqKazFlROCYX1uOPXwxMaPK/YUgwp8RIWRzQqZDa8MxqjyKnqtHsMgw7KeQ83YyfGVUCU9GpCbegKTezegz3H0sgUPLsMCDvgOcHVlfrKdt7OvvwP/X7kx//57sfXrz75+Gr2bn6x8Xv2cl//vsfR39rbEUgjR2oN3tv/OBeT/Ps2ig6mfBs+C/xC7PrwaJKUZye/kuQfwXk/Iv8lXAxlrXI/yUI+SuRtUn+4sIwJWiBf1kKin/VAgj3X+Jf4tcZE+mYJa2qpHCwa+FqhdcBdrUrYx6oqx87CAIpUWzSMQPnssPsawKhSXbxt5zNhwjDkok9aqQiFVO8ZIYpBKQB9GYwRUAaENj/B6+FmywdOUw63GuTk8N9g24mUs2pyll+fZ84g6QrRkhJd8c1+ckpyJWSn3oqUH33bHg8PB42S6JwKug1RirtiMGcn70/IxeeO7yHqcgTf3Ln8/nQwjCUanqIghlqzh56fnKAwHUfDD/NTFkk+fKXjo+AvPLVSfxX2vEfWkClCuBgoPG8Z+b7Qs6xaBr8yxlnw7iFnPpbX+2ss31r6iC8mV24aw8IKkfjBZHg0IQi4NJLXx2j1bxcakP7AxjofuUT3gD7fo1KnMB1g9xJ5Lpve4Ru/KVH7Pofo37mBHC/4H3WNFJ4qtnFVfbnb/3tIspMCJ8g7NMQJNqAFEBRv9HMapIWaVb2Rg33y9PcgiskeMI91LtA4aUleKoDLSdMDLV28JrSWPOBkZ9wnvQYhqL+EcMFXVjmVOfVgJisGhBe3b484FlZDQgz2fDpl4d5k7UQv6MQhHMUOh8uzyHjukAhOk9DBTxZ/2yxOLS4O0EMJrekSrNsQCpeAkK/PHRaoBPTgCtK02jl8CF9tirVQ4TPu2VBKpZxWngKHoQ8WAx561ypsY5EKIibM8MyM/Djw0dYSGT9iAdN+eaUq6QIazO5NQSDUJLV2sgyZHjgoNAFHBzbbqmt8iZSTPi0ji1CjCSqFpsjgGg5MXa6pMJZM+NkwhWb06LQA6vhqhqidxBDXIrDSsESYSgff+h1yERL1ExoqULdqjkbN6BIJoF470JqTfqGtog8u3jnsKHTTqeeGlIDDsUqzUvsN45B4eAYMSIWg7T+G65TB1LQvqwLkoOOCvMKFPtiKm5MV1KFvHO21d9rVuPA5O3Vz5CjJAVQjb/ruRLOzfYijpy8pUkxMA1C7aqcQd1+hw9oyvr29eUWRqfHvJrHvJrtQXrMq9kcZ495NY95NV91Xk07rSZI36b9425GmW6X0v7hP1un0Yai+pjg8Jjg8Jjg8Jjg8PAJDpopTovdGoz9/dpN5uT9unpZD9e0y/cQSNlqaLayqlw9Uy6v0V4MvebkDdFxpEXF9LAv6sa7ClTaTMBfPCEKJ9fwf5V2rbs+LeAfsigYhOngJdb+K15Be2Ij/JgNlDa8zw+J1LBynCENTx+2IFjd8/QBSCphLDFsaUoF/yMq+97M036+Jg4kHcff75lQPJsh4cDFfllPsbKiwktpqZy+2iC6VqRGGhgSe4bOWFFBsW2qFBVT30bHuCK3SS8eKjBIBzwGzQD9AEZczzYlOf6ElJQU1M9WGialj6AeRK7eIKXAgi+BBa8hpyuws7aaACwhHdni7ptHH36VmuFXrhZ+xTrhV6QQfsXa4BevCiYe0tCiw3G5i+TRxk2ulzK30I23X9JlVERpF9PtnM252ZMOAhtDc1+eHya07IJKGnG1wIB9Z9RhBWl3E8ME0YYutC917LvuYpdsGrpigYJYcXTUQFJiIce0SIrOe3CjQWmzUlfTTZIN7hYDphRduHAJQBJVU3CkpXayd9D/0ekTuLxKScMyA84TbvhtI9+xo3e6Pw+IDtmYB+SgCP+sdbhTHBDf1KcZRcE+sayGhgc7QsXZGHq+MAzXdTvosRJn75yQw1qrwzEXh35tn6NEpTtxTgqFjbJXC+goQTJaFAyyw6eKliHXUfOSF7SnQ28b+GptQuiyyI+LcNpaRac7Q26Vd+KHrShUd2mPft/+Jle+U2m6666PSdds/+zo+OXB0YuDZ8+vjl6dHr04fX4yfPXi+X+2GmDMFKP5Zpnay5Z9BWOQ8zddof3spBnQBcx41wQHk7TCUCy64PkAkw+QAsF96cI1qpRcyWsqMLp6HJtamtMwZFJsgFAyVnKuwSTgczYcEP6IztmYVHTKksajEpu/N3djLtUNF9NrDDvq9Jp+0EQzNxcJc3mrQpBsbSYykyU7pAW2jIipW9Ff70TtL8mjlaI2Nrdh2Dbc1wud0IwX3FiZWfFbid17layh9XzFWZa0i4L+KH6zwW4BL+h2YxMXpa4Zg57lJRULqxtl4LG3N863ry99X6WrFAQ3NHamA9MKXuzKAd5YIeDfiyjoEGWn8IWipPMXgVjVlRRWW/fiHbNSBBk5LA5HYSVn0CdXMRPsMBZD0bLP9CBJ6xkzUkOZIehKH4waAxeGOYhEEDv+Yz//AfGvUpGHmKU0LhTKcMC1vaqggWtRkPMLL+2NjNDzajRAlYeCFiIc0lxtAQwCPL8gRvFbTotiMSBCkpIaA3knLHBvbmAyqlg+IONFiKVJpzqlw/EwG+ajbW7/mzTB6PepnBUhTe38QuMeS5H0bU4v2N2wnMvNgnLcez3pOo54XHWGECOSSSFcANEk2MdclINiU6pyDB/RGrtxx/c1dhXnIcTRaoEYYZpJlXQF/l4qcvX6InTmAaYZwETYMsbt3w5BXHAo9XD5z/cuuvKJ9iXzvbr8+iKBZQiTYMWWEBPbnslVoS0WHXz47WuGpgvtmw8CV3AxMIRmpva+VAywY6oke2G8PSxYPAnaXgqFaAGufY0v+Nlp/97l20108qzElWvNkLHp1hTpOhxDumxMQKGbFKzCjRgjdLDcxm+1yOL1Ak+6+7pvsIjaWIojDmlPL27jAfrRfSqpe/M1Dn/ol9DsbIK3IZpbLl9SYXjmY95dshT7hM2JHD+LFxV7g5rUhX3tltvl8j9YYnUUJGMK7mcxX8nzKhXmmNCi8LzKN8DPqGFTqRbIrFyemja8KAgT0NIOXluScWIRNuFWdXXD0qpSslKcGlYstrkzISfflTqENnxsdocbE0QH5jp6BlOO+bSWtS4WSM3wTVB1oFW/Dko7eAyoZeMDQn05PCwdA0X0pKWTISH/jJh1ZRTTCiF4quydPmQHIN2Phu6BS11tqnHCSoaYV5jXGCWG172RlT9QgmaIYI0GJGdWZEEmqS8vHdv1gZzh7U6OD53W9XfI54Li5zEjzjlbXCNnOD9ds8arZtg3LmoNZHcqNYPQ4PitxlGPkWyPkWyPkWyPkWyPkWxfdSTbHQPJ9ruRZD6OLFIWXj9bblpyfnF7Yh+cX9y+jIpHS9Z+tgC0vui3+yWPXbissbsI9qZNbIM8pKVASCjcsXSJj8UrH4tXPhavJI/FK7+24pWutAi8l1jQ/KM1wU6+MEnbHmPS36Tq6SdkdSEH3JxqksmigIbPawKaJlzkrsiTp07Iy0ayDJW4/Nz2TR8zsLm5gFUzVjJFix2W23jr50jZk3QKoAf/CZ+AuIce4Pppu9YSz5OWEGDZ0YRmSmpNFAN3lateM3IDwunLJTRYMl3V7xU9mbw4Opo0FZpdHKf9Lmv21e1qIdCQihB3l+ysEngCi9AxdNFAnUvzL+kN04QbUkmt+Rj9RIF0wtBAQknqI9KsYB2C6msz4W32yu5TxRRnIgPflNY102gXtGMpltsFuH5e0XyPjvQwru8Mz3NM3I/BDHDl8sSOdjMuptDp2PUI6+xo/vxb9oKNJ+yIspfZyXffPsvH7LvJ0fG3J/T45fNvx+NXz06+nawrUfDwDSQ8hcdYWnf+e8Jp01tU+BACbB3tgzQCn0eo7lDIuYb71FwG9MTrlB8LGkp4VqEi8XnFwP4eCqfjjU80/JS8USHCdaQIpw3EW9r4pMBiZw48u40510bxcW1X7itO4d6qGtweQeLMpDa6n3zRSu+t0m6xBIuyuKW0QgNcFjekUMsJeVtQbXjmfEgJmmEJLvfXi2nUt2ttmGrcitB/8XdGje4OwbXFTs4mtC4M1ASqghs04MtAj2bgyGFMPiFCEj9G6P7RU4YwXcNBmnSaRAWYnRhjXI8ZGL9Fp39OuPpWpws+9K5Nl1iO+nGPnG0wSSvRgUsmCoNfyRJOCYPEpGA4dU3omsQ4aFFHGDRUHBg1Nr6vPmX6e2M7dhdovv8fPkC0uSHBp9LQebq7EnkYVDuQN4TaU4PB28xge/OWznMbp6SB/LqlxYbPhmllA3S9NNS/+GSF9odvrXfEed8OQIWGgMNm5dHmSInHbY2vLfUUOYfbF+kRcr6tR4/QF+IRwv1whqO0kFDHevTZ3EII0qNb6NEt9DAgPbqFNsfZo1vo0S30VbmFsB7e1+YWclCTXbuFNpfuu/EN9azz0Tf06Bt69A2RR9/Q1+YbqhVyLGcY+PjLz/DncqvAx19+9vd414mS6LqCkpqY8GYnMgBORRXs5cdffnbV8tybIdx9xshYMYqpE3IuCBdGEp3NmGUueFkaQH6W+14Sz+Y3sQD03eYe7tC8cZdzh25VDEK1/r35fD50RqlhJveaZlnImckoGAoAnyVdYJC0C+K1GgGW9gO8YlB5sYh5srS5NOLybMDkCw0RNBu46PpYTBq006kMbU3cLd4ZAjraYHMJDbxOFJ2Wu+vctG+lbWJZq1VB6MS40hyjv4wSRBtZ7bWMnaO/jHxzEteLBRVuB3SLZ+wwzfx8gqLS0j+YhHhp99Ol5UBgda1Z3K1FYnvB8g1hXVxAm0CQ8KMBmc8YhPebRjsWxTIptFE1GBwt9WDkuDf+NA1PqRrT022suf2nJyfPD9G8+m+//61hbv2Lkc2ytP3NgR5SWGGzG1ij6w8EJKJDPlJYbVeVfi+Ni0jnoqc46CCtBZOH0wlFUf1mDjC9hup0e2gGCW+FnLoLnv2Ua5dO/FutTQzl96VhLWNb2lwn5G+Fz8KwFPydc6oDoIMG4+31/N5pY+1oS35u6flaJzv50Ht+4YbvbYIZYTC7UpAuoKFPY+6EBzkE7Q3X3Da2S39NbhydKU9OnnfTQ0+eN+aHNK9dnUHLZ2ECR6/BbgHw4i9YYKB3DYHkLfpadNVh5/8G7Jx9gkLASRuHdBZIVUFhGnpqCWm/hcOYGMaxalMCO3xqfEUnCvONaxPeGiST4WIxVCOMGLoplZWJ8ADo+ObIfd1ywDU8zGTMzJyxKNEhmWouUU9oySxUkHa1t5cw+nJyB0ay12KpmAY7Ou0VvQjvEpbU0ZV3fIFNIw0SPpJC0NCI9fpMwyunbndcZf2FfOBVFEHQH5jd0iCXnXLWdJ99nxTCoLdoB2JgBU7vJPYJZ9odBX+XwwY6ZkYFfMZzn77qtfeQcOuEIhwz8E06LJXbhFX9iSaQr8j68RUYPv5sm8ejuWOtueOLs3R8sUYOzdQ1nfrbT8LZSXy6AX/HMTyXj3GZ9j7vqgv56hVBsjjgruz1zpUWmsm5a0M6Z+MQNwJhM0m9SSwfQZXVFuoAqtcvNmfJ2E/ic51kN1t7S/jFzAcGfK4uSQmFIOo6QF3SCVX8c95dPwq3obfN2KFIXD0++j94UdDDF8Mj8gTR+L/I64uPDqXkwyU5fnZ9jI0qfY20p+Ssqgr2Kxv/xM3hy6MXw+Ph8YvATp789OPVu58H+M0PLLuRT4mLZjo8fjY8Iu/kmBfs8PjF2+OTVw5Phy+P2iViH4tO90L9WHT6sej0/SD+P7bo9G5B/Y8u110iGiwX/OabAzvLKRkz6MHj1Ia/41+Ngf83fP/aWx4yWZZSwHch5tHfE0CPLFzZD1ch+pslAYwAWqtvQt/qVzZDcAtsjGwhGxpesj9iuB4OTAse7JoVNbNTdxVtvVzyqaI4n1E1a46Oa2kMK8e/sSx0wIY/rteu5H8HgRUwC1vmG00BOl1YaBMCaGbfACDqSEsneWs/alWrhJIyec5dSR+rpkOgqguqh3lCca90D0l/SPiyHVwBVgQtiblubGSHOrqbaIkofW/l/sGgvWTXHbiXRtuju3OUFbLO40F6bf/0ZggIF6cuY6wHE+/cr6gaZ41Ptd0ilvvcDJrn1/DCtR/SV2GTKj1qjTXDB8NKSUua8WYeGIL75eDTahpKNU/3iaWXH6ScFgxX7HbwL+TMIhPTkIo8PTQhcocZOgyAwVLX7Ebvyyv3OpnDp5XEjLjV04SUpPD+1jNtQGCtuTal4WQ2l91znRzD1ZO5D4bJB5vO5dg8L7hZXG/AXFd/temsjtI23bgOlW86D4bbbTRH49Ul/CCX2Q1QqWMIb/zfPYcLf4P8m3ZWhfvNHm09k8pco3w4JRNaaItKKrKZVH6+g8AMlojdABbplR7LuLyTGGkESj+aElT1f9K7HUumKum0K1vWzma/So/SlrO2vtxs0rtPV9AxK7RlmVcf3nywGs6cGElKWlk+q9m/dWBpqBtktcpBVovec4srgiAMPeVaeRfp9kf8q2eQc6svJNTqrLD2c590OEwIFBqt95GnkxhvX1+mOTQ8JMWwTA8XZTF072FeNVUuElmKg/hly8qKoK+m9OVb0zCF+iHGUhaMig3RO4kYAfdb3PbuvFIPxzUvulN2dzQI7r3jV2+Oj77b2wycD5cEZmh2LnG7flOP7S0YE1Hc3v+UPusZOP4eFJymthIHJenOr+Zk8aO13KwB9Op9bqO7knn/Ud/qACUYqKTrytw7Vd3DN+8604XMycfzN92JIGC+otnDLSqO2J1M5h02e8/JvK2oOxmyqPWscLOJHM8tadWdCXwTWCLyoaZLhuyfUzHIRdPMPCxC47hL0JqzqpALCBx70InjuEsmhlTjSV08+JKTgZdMvUbS33XiMOzaafvVmvvPi+M6dh77WnS6WvSM6+uhBy4eLmx9XDftmbENy2WfNlWsfGHxTpsEslzhplUZV/sDlqklZxfv+lfsow7QmGUkuaWKy1pDz2tftXbl8qXq2iZW2Hsu/FdpKYPukGkY4xZjpiEVsYJ+ybShZbX1RtVd1pdEctn/IA/wlBxvRq5XHhJvPUD7IBek5JmSmmVS5JpoLjJGPgr+ibBKZrPWenw09xZWr7MYOfhRFWgmDxHYoJZRkbtIRTfQQtCSZ1ErIT26Ym+o0vKTvBwzrt8AVLHuxCSx4XToApJO92KRCiJvmZorbljrctET9HtXmOwQA19zZoF2sAOqNSvHhQsc7YE2RGE6/XSYyXJNCOYWy2oFhd9tYbOW/biF7ATwbTCeREMuOTBrSKAvHBIgSmIhN4EjBonemRr7gkEROc1I0I0ASsM07wrRynhLhKwbZLkxhK1o/7sASc7CKK6YBTQgiKm3WIc/dPbwUGN0/2pIw1XWmOVsfCue1XRC3fkcGVO1/fxuU0jBKHqCJYbwh54HG26JG6cNYbrivvt7w6g3k3lji5bpWCv3NVkqDrntSlcsNt1bRnOmdA+8nfsGIUxYbShv7vWGK8mokIJnUB0/99P6NbksAZaTH6+uLjohPsnu6EoK3ZF6a7cnVf1rnaZaJ6O01IyVa4L6fTAYRhm4hTjwEcrGZizfi+jcE1zPGmafpXaflbB91NEI8t4Ch/FNRhIucig7QuazmM7hwSUFnzCSLbICQlfBAwdxrjKDfkD5luvpIa1llLWcsNbtwXZk5felwd4a1/vVTtXriipaNpTWlfbP1s/tfWz9rDNasPx6UkiaYsc+5mJ6PaGZkeqUHB/Bf23+C7vQvzcrUXlGJgU1pKRV5YRcndZ0cOYKVF4NJvK4dZBQZsG1EWohtlnZakPRsQTKy0atzHV+4ftfrs/LEu9+PkxT9JTRYyXHTlr9DHjpEVkqDu8C6ZJiWfeFjYlbrqRoqid3gc/vXDJgjwG6oGJa95kmWqx9leht7fqdBW/L1TxVtITYUQ8jRgz3QdDd0DsD0drWTeAIUrIW9vr9paHSgfVnYG/J1IkeXrK5VDdfGsoCYH8G0nonD/ad2LjqbleD9jru66KAYowRqKTNYyKQ4IJ9T7/aVZzEFRulipF9N/Y+lp1zyT4Qj5YGqEXX15JxhDQeTBzJPoDUJF1RoTGqFVpGdZd3/2ion/BlSwGhMqJLo8qY1xedhNnXvojeEzYdkn2ntO8PyP6YZjdT6IP4mxzvDwgz2dNvOvSzrWawK8oB0jl/48keILPKciy3jRbDMbMXBE2MbBtQoY/qF7kY1+K1z0Ib8zPufK1/QH3rfTuu0jGd+Mafok1tAUrH17Idbn3oXuvr1WGb7V57LhKiZNB4ri9DMhzrNpgk5E026442eVanrHxM7gLXDZRNDiOGOVy5WyoWXhS3NfgHPwguEy3xUG2+hRt49lft4U/tz5eA2RfBoFgllcH7OxCf3uTs7crvvgZfpOWR31h9Sideqj+t0mPWaDJLPPvNV9YuqZJdGtxAIfwMSwrhHhuuKAWqGQzycDD5wJANQNooRmob68CHyoWRg1ut30bQ2V7ok2xYZmp1z7NjZW46mpceAE1UIOZUu661UFp+K+HWigO/B6BtL9QDAsmrDniNR6vsLRcteKjxHX7TtP0tgJENPzJy5phRcwkZNckbjSTVjRD54bKV0rBE5MpWGs/wXmyylTC1jhjWrMOvJaRFbU0US/di04CNbc75eYLgCrvqBhdIbEDhW+RDLaElVePXqOFUTVPy6U+XWoX3FTj38S5UTeuy0aEY/3uHVZGwswkWNKmUtKyFaCY0N/y2eZXc5lRUPXpVy++xSkuvoWJ0wHC8aHizY6sr/2YwPQxQHWD2tb//3AUqYBn32+nLJlJwyI21UN8d/4HuaAXXhgnewOod1nTmit6ZRtPzMDhwh/tIr37fxRqg2pl5ZxfvXLvgHmA2vyfeCZY4d0yGXQbDdUl/k6oDyXhhNpzsnf0++MKdM8YhIdBPl7Q3MxTdafmhKQpYr8YMOm4YSUa0Kg8QoFE7tkrfk8h79e3+VS2F3FeiSMmokNMp1vlOq2Es5R09V9ctgTjvdPS6IwhptaCtoXgLlYDuAkDM+ePRuNR/VV6XE9GjU3Y0yuV4vGhkIkdFwMX+RW1mQGJRdwyTcXVGhg3a4BoqDo7BlpBUSvvHwfdSzakdyf7LOaAHdvZoPpxwpY0rK+XsKxHAUGix2YU1TAueVTKTc2Y5SsmnMwM24TEjglmpQhUvFnYPtLHLwQqGriDaRKqpsyWQkhY8gyjTLTayVd9ltd2jVfZl6f7co+hLUu0lDPcgVV+WkmU/E1xCrK0iJ1ufvM2rmKR5OA9YyOQ+BUz21jEngrWPrpdmc7NPXV9z6+EqbUtOzBy6R8DG0gzuXNwehBktJtiRwmJyQNh02Dzt5NAiJZPluFXxfsVytpA6yyRpX8GclatcVn+nBVJbxdkOqqWzr63y0Knz0AQLSxi1oGpefpfB5BNZmyMsFwDJRTGTImNK+PowzTpK93dqLNeS+mo0rYGb9JZ8anMZfR8ULrWBrFSN7m/sWOE677VpLLdoLMf4GlvHfavBtLbV1YTpWdAWsQCrFpOweMe19+6xWLG8WE/PEpqB/A+3BCy5dP91bFL0qW9ZaVWth1kYlsHaZkV3Kr7Vs5jtYj82W42rHXSfDVpapqhnCY1aWw+zgk6FrPusZV11roa2jB2MSyYMbWYLNeJJ+yI2G0CdxbI46Yi+gaUwoR7L1JkPYxbagS/Q47j92cU7rHHTzkSDhwfBHYyJd1K5pLXeCrR3Kf3zBtpz0crUyl1EhNPPQvF1yFcQ7mqGscZOcaNJBG+jJey2tq+NnMkurexkMzndjLMA3GxlXszqolJ8k9hKB9dSz8T3vrGnvcW6a+ecahc2yEuqFqRiqmJGUSNjn/RmJeQIGewqF9PrG7bYALwVOPrBjfQTW7TMCRhcbumt1hBY5CftgYd9ylhl+ix2bb/JKv9Sv7bi7mv2Aj1Tci66G7nSwdvOI9jGEXPlyQYllmYGo/IDOZEZrSom7KUecnbsER1TnX417AerZFo3Y2nJsvsU6SewXmiDqQIBcLMsg0HmdXEv5OAIMRqhYzFxe9U7fcvEuW7ycPZ7B5tRkTfjBsmq3IwNUXqOyRhSJU1H/OaCr4rW05mBnjZowPLtwyHfBHtRfNNj55ddC/8WB+WsWZMtnBlfWcMeYK91bXdYwIl2H4LQ7JYpbhYxEyqTKl9CAMBo1PV9QjDa3fNxyNAkvhuTu/MTGAvmFYsmK119GCFt5XoVUHfyHJ/hwMxAuZoAA3lr2dU+Nu0U0tgro2AZuAH+h95ve49DJnLFlFn4UaBhveFFAbY/rsC2KfKkkQ/7vaaF9/02VjiANhOQslIVNGMzWUBakGLwZ96FwPeO0tzU1Ifktka1EDVbvGORQmLkFM5vKGej/cXaKWF74BNChfId9m7f668M4F5ymWWuz7uzxr2++Og6yJdSLUiNKw2Rw63SXcsu483yN04F7VP3dGod2FjfG+Fno9gsBJusOnXarWhJbE4Qq1W90sKxlCxHWVV3prZ4g+pmXVtE+5wYaWgxFFKVwyrr6mZLErR8J+2KqayZLrNGf3QfWNqSE4ATshR0lej43neM9QmADVFt+rI9E3cB9+Z0OJdupOgdtDNlUkF1mJxwQxQVU9ZMAgUiOrK0fnx09D86NigkwjvuEn7c2ShH2NvsVWeLWmEDfU3O122MHdfB0o2xp5mpe6bdRsDCCPEUsxx2wd74+0RpfBKzVVifcamdvbp07WvYuoPPzuKB5AIHGZJzcJ5ltMjqAtoeQ8Cvb3vx4XJIPgjyMxf1J/BCSaG5NjpmlIQxW5NWRW2HzWaOJsf1ZMKUhuE+XP4D+90TSnQNIUopcPZ1OzgXNIPQGLd19tNf0XoycN+DxGiLP+l51tB9aAcfdQi+Gca0LcW7rxOSr1pVbwZwKgPHTxh9i2euqJOYsM07EOamzHMVbS5loGtY6ComukFy+cMy0odmpV1m2kZb50xssHnv4JtowbG7xKEOhUVHX8hTpdiEfzole/8F6P/vvY22VPM/dsluIF4EWO4tVylnTPdsRhsLCYmOWg+70z08fL8wDV1ayCUz5JL/wbAJGS2t2m6poAdkmWV1xTFyArKk3DtPfjl793SYWuww16KkVdNqd5k8bkAYfiATXjBNmFA8m0G+mJmlxZ5v+JgKinuKk1xjUGHY5wMSJh+mYPTqg8nvZPuwjg5ZNZLD+79dszn9OeF9k5KdBeW374g++gAsbAFljdyxPqD6LPn3gsvjZnkI2rgWeQEUwZqle+5i6fvZV5tpdHK3K4fsICuW3UXNzufDOcDMa5qHoQJbSnoO8EnzCFRU9Nuul9VNu+VsjimUni4dO4iFvaDSwbVhZWVVm1Oy9x/2GzuV3msVNqjoHbNeO7uw3DJNNr9HJJGvFRWuwy2hmszYJ8JEJnOWd50wW/q5t6u69gO6XuwrfCoCbVCE0GeSZrJi+DhJ3e0BccOAxe1AvHOy6ynZz8fDSmozVUz/XgyhStT+gOx74hkyNbZ/g0brEmB7lqXr8U5WdkYmtQKLoq7HBzm/5WnYBkRnPgGTclzDgDSqWD3tSZfJem3w94X1Kk1KvOEC9sNlHXAz89tQjw8A7rTTr1PGgKJwPa6tqLM39SxCG6q69/pt8i/qtm63RN3oxcMaXNj/PkwmmpkO20zOx76O1RV997iFN8nCAlNuMGhXOuyhw7zGmpYbI+ZPwcwbB+W2q9MLkZHO0rarpe3qJumGqR4Ib041wUqhlscuRDZTUshaF9BClDaeND21zRIKicS7avzQNALHn7by3T54uYYNhUZ/wB9ZHvRH7hD4t1TWpPUguiJHczEtosaGVQx+eHtFDmvNlD485fl+H9fe+LSkID/8IVqnmMKdKm+cGa5TlGxydhTTdXHPAkBXWGOsLkxSYiEIeyzNlZZSs5RsHx5gYnmevt4HY0nVzQblstc1KuiJylizsLNmpaykghZQApi9ADhEdFHw5YiG94Z/Hf51y4XcqWZYd709XLOi4hoY9b3EZa5kVS1xna4j6mgaiDdtN56rhoJtH5pkPfzm/w8AAP//x/rymA=="
+	return "eJztfWl3G0eS4Hf/ilr2m6XcA4KkRMky5/XOsiXZ4rN1jEm3p2d6HlGoSgBlFargOkjB+/a/b1x51YGDJGRph5p5bQKoyoyMjIyMO/4U/HL209vzt9//j+BlHmR5Fag4qYJqlpTBJElVECeFiqp0OQjg65uwDKYqU0VYqTgYL+E5Fbx6cREsivxXeGzw1Z+CcVjCb3lG31+rokzg7+PhEfwf/Po+VfB7cJ2UMNysqhbl6eHhNKlm9XgY5fNDlYZllUSHKiqDKg/KejpVZRVEszCDP/ArHHaSqDQuh199dRB8UMvTAJ7+KgiqpErVKT4AH2JVRkWyqGB2+ir4Tt4J5O1T+OsgyMI5vLL/v6tkDvOE88U+fB0EqbpW6WkQ5YWiz4X6rQZExKdBVdT8VbVcwJsxYII+evPtv4SvD3HM4GamMkITjJhVQV4k0yRD9AH0Af27RFzD/+NDsXlPfayKMEI0T4p8bkcY4MRJFKbpEqBaFKqEL5NsShPJiHa6zg0r87qIlJn/fOK8wL8FM3gvyzW0aWDQM2DSuA7TWhHQBphFvqhTnEaGlckmSQH7R0vywQKyUsm1hWqRLFSaZBaunwTnvF/BJC8CmIhHKIe8T+ojwISbvv/46PjZwdHTg8dPLo+enx49PX1yMnz+9Ml/7DvbnIZjlZadG8y7mY+RiukL/vOKvwciu8mLuGOjX9RlBdsDDxwyThYhLNis4UWYBWMV1HgkgHbDOA7mqgqDJIPlzEMcBL+XNQUXs7yGpeIxjPKsCpMsyADveJ4IHCJf/HcGiKD5yiAsYEerHBEFWBVIDQCvNIJGcR59UMUoCLM4GH14Xo4EHQ1MynvhYpHCxvIqJ3l+MA4L+Ull16d44OM6wp8d/AKNlOFUrUBwBWTdgcXvYG/TfCp4IHKQsWTzBRv8Ez4pPw+CHMaYJ78bskMyuU7UDR4JQF9IT+MXqjBIwelKOMhRVSPa4IkyuAEelNcVoMdSvQcDTAWTF8I9goh3FgADLKnMIXzYT9xcmHpWz8PsoFBhHI6BlZb1fB4WyyB3Dpx7Cud1WiWwB3reEjYlKfHEz9TSTjgfwymJYXEwUZ6Zp5sn4rVK0zz4JS/S2NmiKpyuOgAuoSfTDH68Csf5NfxyfPT4pL1zPwJ8uB55rzSUDvMEKoxmepX+Yf3PPUs/e4NgD0jq8d5/uUcVFpQxpQhXPzNfTIu8XpwGjzvo6BLQSm+aXZJTJLw1DGA1dSVccFLd4OFB/lnh/TbRtJ8tEechHsI0xWM3gHkq/gNIJx+XqrjG7WFyzZHMZjnuFPxahR/gpzlcc0Bcc3xAhjWPNQ8ncP8sSutYBX9VIbIBWiuMES6B45V5UNQZvi3zAnuhC40WOvyzLFWGLGfII4FODDsmykb4wyQtNe0xkmDcDM9JzghC2Jz16fMOF0vhMu8Z8AaFFIiLpZNqlkqMHRGQCTUC56iAm+Ge68WeBuc8XYSCAMBDi6ZziwdxYOEbIikEIoiM4amhc37P3r8hkUQuTn9BsuMA6CEuJYHbLrC04TLfOFcadcR1Sc4AUmBqgcHxeoXBgOams+C3WtU4frkEpjwvgzT5oIIfwsmHcADXVZwwfQBtR3Am4UG9KfJ4WcOBAAz9COuswnIW8DqCC0K3oIwPIhE5o9BIK/Z0qMUM8F2E6VWiuY6cZ+CvKostL2qd6t5z3TxLr/QcQRLjEQE4CiYfwAoj8hHgCTkQsanya0PXWqbBmwwQjdKBFuDCqMhLvPwBAQWepzEcxxFvdxKPaD9wJwQZDtN4Hp5Mnh4dTTxENJdv2Nmdlv5zlvyG4s326zbXLZIoEza9d0P3OhxLIuMk7l1e7C0P/3cXCxSphc6XyxFaOwgr5qeYHfIVNAWxjcQW+Miv8dPy80yli0md4iHCQy0rNANXNznI4nyg4SgCHWSRiDENflTixMSUkEjkOg3sdaoWYRGKCCLLB9pRKmb942aWwHFrTWVONtykOBmK18664R4GwVdzHloqsyT9FVwbsPpUTUBVmi+qZXsrgel5u4gbtYtdvIRX+7dPczucAKSdcAk4Tm/wPwa3KAqWM02avK0ijfO7eJsPLWoyw7MNVu2zTOIyBQxnHqErDIjB3Xi7Y00C8DZ/DhIEqgRtFLvjaDyLsrkDVP9N1Fgf2Q2YnqGOe1BEjx0xJkqThhzzwn6zQpA5kzeR4GI1IYEv5J1LsqRKwionpgSnUwFeiw8o6WSKBCo8dRo2FlAKNQ2LmC4uvJfyDPiufZ4vrXHCmj58ASx/kuY3qKGhTOeJzZcv3suofCosmC3Y8At83IGMuAjcqEZcwWcu/v4WtCZQTqpHwEtpFpa04R6tchDBWlOxRovXijeplrMKUtcVKkVaEtBYAp06K0MCBrStHEhM381A6vRkpUB039Nqel7sWam+UBNVeKBkjQWWLGbIzyKD8s7CidAyGMmgDgIYhADBgi2SbbZTuPCzNC1EpCfAk1OXNSJERrXCH7wP4P1aZ7wBJAuydKeNKEHHaBbBcBW3xkSuzht2QIdMq69G6eXxDvVExkxBzJrvCdSESwX8vEoiktJBcJErRX1kYWHAHFwf1NJcLPDYdYLrBbXPSva4UlWQtF8mVR3KfgA/X+Z1YeaYwLI09SWZvtcqNc0LkPrhUc0RyypBa0OGsq0QLttGkGvCnlZIH4hTRBjwo9QIXSB2FvmiAJpU6XILqQ5wAngqff51fwIdkTuL8EJcMqEwX8NnQMGc1nldAvBEzvSO4dg3iJYSxiKbEIjAJSnN5+8HwI3ifI4bgKaaoM6Sj/Ag0gkQ2d8tZuWOIKOFFQtgoiK80TBpwh8N5YsRo8y/4jLUAOwNFtdstGAVdDRMFiMEZTRksEaoxoHqEouMwQICCHL2NkL2Ijumd2W8rFRjT1p3SpobWZ9VC/81bx/+ij+wWmEse7IfqDcjP2B1oHm/HD8/8QDjRe3gtpPzy+MPvTmnKh9GoC1f7UgyfQFj01St1b+B8wuiX9oGJ0f7JwC8K5jeOlKymawF39u8ANZ6BhoTUGAHkDWAv7xKyvwqyuOdoI6nCM4v3gU4RQvCF2e9YO1qNwWkzg19EWYgyLdASvPIlen7wIFHrxZ5YviSb5WC4whXQMy8Gi4t+tCCYP//BHtwcvdOg4NvngyfHZ88f3I0gK/CCr46eTp8evT02+Pnwf/dbwHZxtf9semf4fgfaF7s/MTinkYPMFsWvvkGht+mINrABV3ACXKZKhoOgbmTzOEwzxeaZxrVhik8Kfg2jYDGQehlyQukQWCjWT0fq2JAovwssXJNaQZl8NJgMVuW6BUwprVIH+vSAeFtXjnuAzIcosG2Bs2UWDggWq+2rQCMQS3Ms4M4au0NCLvwxi5P2k80w6qDdvBvL/rg2tFRE5g6T9q/1aAr+YhKFmtgMA/4xHn+3lzQmiPSZeFSFlsB0D4CRGNs2ufvr0/wC/jvMyt4NO5a0Pd2gJs3Zy/6oHYnZ5F2i6vem+Q9v32ri/2xDwfcJLcFAl5dtUQ4ZMUQpO4k3RH3QuYV0AQa4x0AgAyfdpyDewVivwxwGpqWWFZ4DUCh3aiF/rMU2FoVvEJThBKByoOXpPbhziytbWvjRCzrNLExiJCWeLiA6wllzA68Mpw7RKwrCfFkbSBmYTnb0fTaLovzoId6hucKzkahUC/1zPoT1kDwQbxTsjxbuk5CFtMdpgUkIybLEa0CTdGoOdAHXN3IuJLgvxPeKzSNO3OirAGqrdWYA+36bXA5mWEHnO5dg+nWTdIyDJBgaEO1o9vpYoaMicUMcvMkWRsQ50iGdCS/cu1oec1TGjOa/qLfisYRHwGTR6yZMA0VkGloUoTGDWwdXKwNs3VYK3VkI2a66XJoTYI3qgLBnw3NpWvIDjEQ5jGbsZFCJqqKZqAAopTljA6qZyk+RAskUpfv+vZ8mElpDKQ+CDIuQCHOyULNAWb9dACvl0ATzkxNyBimMBDvmV6Q3vTMvioSou+l50HtQOQmlMn1RYjDJqUFVRC2jb0kIv1ld5x5/9IiiOci92gxDbPkdz70SWxc3nLKlkGcTCaqcG0mJAcn5OgFpNLxPMCgARhQZddJkWdzX4iytHX2y4WZPAFsf5/nUzjZRP/Bu5++D85jdkqTybR14NuS87Nnz7755pvnz59/++23Pjr5hkxS1O9/t2aR+8bqmTNPgPMgVtgWQzRNR8UeohZzqMsDBef24Lgh0oonYXfkcK49SOcvNfciWPUhbAKaHBw/fnLy9Nk3z789CscR6HRH3RDv8Mo2MLu+vjbUjgBOX7ZdVvcG0RvNBxzv1Uo0Vo+HcxUn9dyXkov8Gsi82BGUntGHzpqecKgPpxuAFd6Aqhz+DvfIIJhGi4E5yHAy42SaVCGosirM2jfdTekti7XEHS1KlMRbHjf3OmZGL9jXV7L35QrnlnnQd2CIZ6EVH+eE7CxUBFxN64gGCjbPiw9KrPSwd84gTrClKpWeFx0KjgBJ9xWHr5qhS7kJsyUiCE3eW1xQO5HxRAi2i09i/wwnc4wG+0RqAE1mTKMMEAYBjeskrfA67wCtCqc7gsxSlsAVTn0AnAjQ1bM7kaArYkGbzJYmlbBKb94d7oZdszX+GG7CJLsrdsKjA+POwilKb8RPDB20OAlHoDpsxPGiuYzkZePrFazEeXS1u5WlZ+dpsqayyefQj8TsGNPxsK7zrTL3Ed/q5+j781yXGzkArRjLwdv35AA0w5Ij8L+3A9DdFG0slCj9xiH6ZF5A9xg8uAIfXIH3A9KDK3BznD24Ah9cgV+SK9C5xL40f6AHugvBLpyCW1z2O/EM9i72wT344B58cA/ivwf34BflHuT870YG+CrDwRtVhQfu7mjTomSY85SbKO7rkg46MsfvlpblZNWT7CURvTktBjPkh8EI8DGUh0acxKPBsBROHjskynkNCjylMtFhSFvx3EHwC2raQCrFkiLUOYfLkFECCjVmcBwciEaNiYsCECXxp8l0VqVdjjFnNfS+1B1A0FK8OEGqV9NC4sbD+FcEVV+Z0Qxukgb+Ay+5tmwLi1SIwKWcosg9K/Yr88XqPFNrRY4oKUlC3HlAOkdoM/4AuDF4/JlTDOacFsXPkeWaMyoReYBNcsMimnV2KfEoTLwpbSqmXhbtfVKVKp1Y7yvG0OPoW5ifdiQeEzJpcK0isJlQCYC+ILpDa3nH7dkBgZu/3g+GyWHvXKzOxnZpzMTPaxozX6zJZeb97fKS6HSGbkcJsFCBkB0qBTA2l1YMSZ5ReryfZITko3kKEhRumZM+TJa/Ge9jaLOBNZP+0abxE2PRqc2UW4PWYnhHe5/wWxzIjGEzomEiuwgZTw8V6gzbgJJIdaCFhE/YlCiW3eGW5cwnEcFlzFCbajHpxBWJB2y87MirGsNXSuFMOn8CuGcYeMnSPJmkJHGOdJTmeMkDrmUn1qOblSUZco7WUdC4yZyU0oicr0If3URzAqgb0c5jMqxN1faw7lKLRflcARTLAJkc5cPIcLGDeEtw13WK6UPk4U9sLrw8XKIQBB8oE36bYI8NTEG3DvLg0QGxCy4JIVmQvmNAkmKNsUOyz+wBTJxKL8PgnFyStHtWupjBdo/4AZ11NLIZlmYj8KyPCCEHoCiNBsFISP6ASF7RV5gEeRAVCgltxKk6ui6LGdEkYGuKk5UlOM+cLDvtSxKFroNFWJaIzAPOxvKvCwF9F9vxig+DzNBEvrnkZiBTSPpZNw8kDkkX6KS1K2ZM2h3KdmtsDhMEYFn2FNhHKWlg1lAVGjANXHZkLR2FOjPwl7DAw031DyY1xZwZ0QdgBFFoENyoADQ4MgtIvEEQmiFTKbYRRpFaVJQDLSEIfKdp0WkAY1CVJcxpJK9UFNbdtjPaafLfWdZgNpkpa80emwJIzX0UIudBWlFs3dWRkCdRwSCzZsz2RprVqeacq7rknL5WySAhEhYg8agmyNYjsb3YIk8m88/5ym6rwGrGNBy1oyaTqRXTZBWwyXOMrLC5iGRARSK6yW09pZLdaaAJtqVkPtL6Y2S9VJFfVQigjsglKdadFMRvfVcRnuSmk0JQJMLLpWMDVbyrg7aFXtXVVLCIk7AgNM42Uv41JPMcLj5zcQXOEPv7JMnqHcOPOgQM3vug1CKoF0ys9JJbjcrHKqWgE6Q+HpFlspgH+Bi4O2v9gx3aNpq4S7XOrHYrTubaQ2SaRoY+Vg+Co8z2/JE8MwoeIWeHv4JDuY7h76+RnrVlnCtLoPAQlPXYgk/qzzyPa3idWJ137Fw+yZIB7mBdIK0B3UkRKRjeTOoq/Ewi9ieeBjdVoKWH2ywG9qDyY5ziutjEr9PhU228mWSLurrSP2ZhBoIWrDjudLvuv5SXvQsBl+u86BeC4DNNNy4tnj8rlPqA2D5k+Y3o4HztWjqrus+tPpQ0e8baN4/uBBYZrSHbxKLYx34tqC3O22S6NCjuo/ker6xr13mEfBkL8+nSQI2Iox0a9V6jHe/RQhWgI5RUIIgK54AsM1XFokgyOBiwnxg4wFwfuMkYfVwpSvZmATHIr1lZYRk81njIrgBL7DC565DNrr/O/vri5SdTWs9f4mpMPIsjkDZg7qwdg6aHHW0Kicw4fncpM7mFsZ5I2SGc3YgQ1YzRc0hS06y9nnR5NlHmHGvdClmvIU/TtyM75ghZk0JJOkzDYj76PEU0AtI3UxDn3fWNJfyd/bsrS+ZwqSBXD/KedEZr3mCAE10Lq73w+bL8zY/x0MLWLpb+E3AQsqjoon+ABhQmCkNNP4uQs4KX9IihWFkMTov6qJjnx3l05QQPg5SKlBLzjU0uAhIIVVhEMxVbgsUySIkpw1TgVayutTQ6umJpadTG5AVIV8ffBkfPTx8/Oz0+4pDfF6++Oz36n386fnzyLxcKpABYAH+C/UKhnbWCgr87Hsqjx0fyhz2ZaOUt6whFQ/SpkSCxWKhYv8D/LYvoL8dHVAb2OIjL6i+Ph8fDx8PH5aL6C/BX39EJJx0IaGdxFci+ZIo+DuYVRbUaP6ohEVuJ7GEu/TvWG9kpdaTLzlhrCz8o3ElQKAU6J2GSAvvp5ElmxI140+Y8yYy7OW9imL29K5Lyw1XpHMq+YzpJ87DTkPoTjBDQCFxNL8mROH2x7ZEaTodwRJhwQVFICUQsxqZXgeZ2Vn/INUoKiChrLK+hMX3YA/sVGk42oL/eRey/JcsLehVp2DULGhjjGMrUE7OII9xLOHQdldkwJI+jZcQ3icVrcM/mHE6JlUwzU12I1N2wLOGIlA5Apa8B4hA3IWcslwqpJ7PLYKyJ9wf9RFI7qSG4lrAgJ/Ro20iFC3m9YWcze6eHb9z1v8w4CsqKfFqNtm8I2c9VmBETha8ddduI54hD8rcgQ963Jh1QUEXecKxnpPaGH7C6Kxr6eKpE6STCrITTR7ZiRpt2rTUO0v43DRyiVnBn8Z91i7UKgJgUXRXAY1qoCljTTI8OgBrMDpPG9p0b1epZTpFTb0loXrD6v1PjM5C7WHwSArMvpKZoc1oKh4nVJKzTKrhYlnjXW3uDw2jO2bpBkKKBHjPxbpLStVucWd5rJuUpiVBOyZSY5RmZ9EHu58n3XtVFvlCHZ3OgoSIO53tfO8d1PC7UNXsZ9OMXl3tfk/siC16/Pp3PLXFjNII8dXD09PToaO/rxrHdVZXCnxSTC902IlTX7CIza5Gq8OF1TvmUJpfAVv6mWA0UQ4dulWC0PNAg4lj7Tn9eWVqP6to3nDABmlta+gj5t7CaIRCXbw4VPxH+Sq5z7d0gWwixRVs2D6eT+t1adgNGnEeJLc9LEpmSunpesTfMK8viQzGzaL7B3hnaUJREckAeV1RmCz9Nea7lUgyYRrMcovU/vzt/81+6endpnUySkUsF+MgLzYKNliLauRQhEBabQvHxxno01RgWY9yQ2/ikN0xd6eOBP4a68DyBiHllHM9K/owG+4oVLn9HzOslDd6Tpcbp02lDEqG524El98dPaZfNLE3xwiRqYB1IOJtLBBF4EJLQeMkINS93hFks5G43Ua87C497XyRUVJ2D4ZB1fn/+8ut+xFqa2zUsbsZtG44ka4Vc3GPSL0ZceN0hNBDan+XyKRes+e6geoNAOfhAUPKogovJLxDZEo5Ojp/5MN4vYxDjEUk4sHyMEmkwh/wm21miMd8OOME+WUeKdhbfIqx2ZV59D0NrobZNoyWI/RtM3CfJ09JwDNxpSodCz4bYRHLUXcI41rLbCMeiYDXya4++boiXYTFV1dUOUXFJMxCySeIol/M0yT40IpR3mBhP6CK7KPl/Bth6h4QMgaSBkXpnLPVS4i6Jm/5M3LSwqrYTSvXoosFqmZDd2Kepyl0B7Xv5uEI+g0fcyLooLFBJs3VPQmv91TkhbomXUN+YvkWHLdI2jcQT9EQoi+F+M+a0SkUzMsPbsv0I2fl7J9CFPYrFQVljtxTjWtxIuPl8Muc++6y5zzBj7jPLlvvsM+UesuQ+zyy5zzFD7jPIjmsrC/r+Ml/032CXJjXHCdxFm2PFReR1pDg9IxHg1PxAwTpDczhFKnM8vrcpOfJZpSF96twjE5+Ql1789Wv9eaWZSBfG8cxEUhkf/ZuLuuJYX6niZLo6vbjg4FbdmqnbYOl2ZbJmFe7BZAv0+JH+OlCaxEISUzojfN3YXlwr4dUE88qIs7CIsf/VILhOiqrGUGIuwAQ87CVV6nCq4JARKvihBn6WqYpa9MRqq/oWBYyNLbTqtX6hW2U2LXRkm26m4MzXOucfnz+7euaXUXioZvBQzWB7kB6qGWyOswc57aGawe6rGeD9uSNI9l/L2G7VQjdkpHLa3Wmf6424pYORhgxThedzPL+FgtuJS7S2iiD6R3Onbe5YznELK52VBo86fEl6tnDG8IBc5OJNN/IrirhwA1MwgkSPryxuypKyxB+zSxAxO6IWeYSpJhZuV6mCJKBk0V1xYDcVJl7LVnbPuSv6fLuSNsmYJknqRJUORTqU+DMV7eLADmGSFNT1G/ZbQtO4GVNKfXEJBc6ZQwDEOmdTjSiFm/YaO3+hGxceiCmbFWVXIiPL2HN8vrHxeTmchPMkbYSU3NvV9O4i4PGDR9rWV6gYcIT1wsZJCJfSpFBqXILgfZNkcX5j3f+2uh092YIbkLcrqJsyrxSzIClf+3x0qrhOw+0WQYFSAQdv8l/Da9VcwQcU+T/ZGng2AzbpXBjcXVZFV3HSk+HJ8Ojg+PjxgSRxNaHfoUDTg38dqexgvw/h/96EVqvNnwpiPZ/QPcpGOZz6egzibb2K1sPiJmnRemcphN0BvymNHB8Nj0+Gxx60uwp2uZSw9gb7xZ6GL7wqwtIXVjwPlVsfHYegxsIjU/l4RAXer+c2+kdKbTqyrlHWB27bVac2uOvxsHe1GbHrzu4oTPJQHsinrofyQA/lgR7KA33e5YFmVeVZ8V9fXr6nz9v0DsGXTDjsUBdzgU0u0pEOTFUcOO00tiQgi1TDK41pN7fn6xfGebwcdlSiXReQsbYa7YUXn+GDGdCsTfQ+f/5NP4gSTLOjM3wp6ghvxkooX6s0zTE5JY27od0BLi9zjGYqV2H0EQJLh32mQpQD2sLV8cmTbgRj3ZV8Zzl9Hkp5qka2MhM5ZwFQbRdgUE56AFB+mt+oghK0kYXqglHD4EJJTmwe1XMd52XGLqW+yt65DqtHKe/Vi4u9tnlsqkApW1Chl0VddaKJ2jQXOwvY+kmGt9kzLuZau4m8pzw9PBwD3xrKt3BK5ocN2MtFnoHi+6nPOU+76UF3gfy0J30VnP1HXcP7qc+6QHu7wy5AY95nXXaYereKwfPRx2N2G3dPjnyP2G61OYKrTz0+HrrNRnQdKLm8f5SPa+9uNi+FXvmdnDI23SScTS5hWvwu1MV3OqkJoTIOD6ng1cpJ5CL+XkrzTVhgkZoRFTPDP5KO9E/40VvOLtNodXKal7KFi9FptWGzJAGdcucJR/ydcO2kNKnY015hChbWp9AS6iIsvDqF52ziLEJbJnAkw2oZjanCNYZSy3ld2AVHdPPv9F7IKG7aZyPrUxY7aC1Ip/WaMWfhtTJpRlhOTcKOI13nkKMJ2QigMjitVBOsCDJ1E2D1lJIaul07CgmqMimmtWGOmg/yXbOSAUJJOt7fpysfr3XXDjzWxi4SDO6cnEyeNvJJvFnK2TeGc06McbnBW+erNcX0dFqNH9LBppP5vM4E/xwBDNgtNAex8SMB74KTniMhGaUTaGpmulUAiB69UYOjmTCkC/hsE4Kx4OYYO0wqOWMtDSs/ZByM684qHG5R5FUe5alfQigsxgkcwsJa+QNJV5XUMSoVWPKhmCeYTSkpSwOiwDAFOsXJlnzy7cPlB1iQtZwl0W9wRsNIjfP8A5xnQGfFDgoA5satFISsxpZvssU34d7KYqfKEUVHc0NDE0mMV2xsIodNGQQ+BYdYbjA4f8/h0uWACnuXg8AZ8wYLD7AQ8hlK4WHiN2O77xYp+yxdsVQFVJGVJHPTjoxzPDeAHqmr5uXsj6RiFL0pqfRuuXP9vS7fAzemPqzyE99did2Jsp63EfDk2XMPAcJBquXV7ppRnrHVikpwUvIYMW2nlvz5e64AKdQEdHcDkrEwObMeffxsYILP/4YmwTwEYsrTgxDAg0kilB6zOCy8Zpdm2AlQnbsZPyoQTTgVHbMoRQuaAu+qx6T/IIFQybNDg7yDJD5AWa2jbO/p7N0/l29PXv/zm++fvvn74fPZefHv73+LTv7j334/+ou3FYY0diDe7L3Ug2s5TbNrINIJ3ODDf2Q/KVwPF1Wy1+npP7LgHwY5/wj+DJgHnp/F8D18AO7vfMKKIgXIEvwJKch+qjMi3H/A/2FVZnfMObA/p3CwtHDFy+uAu9rNbR6o1I8dmAvJEWzcMQ3nwmH2y4BCk3Dx14m6GTIMPRNr1GDJA5AY5gqWwYB4QG8GkwXEgwD/S14Lmcwd2Uw63GuSk+DeoxtgSiBNw65d3SXOwOmKYVLS5bg6P4mADEfxY0cFqm+xNMrx0C+JkoRZeMWRSjtiMOdnb8+C95o7vKWpgkf65N7c3AwRhmFeTA/5Yqaas4eanxwwcO0vhh9n1Tx18uUvhI/QfaWrk+i3SuE/cJ1hpQriYCTxgKT3HWajUtE0+kuMs2ZcrA4mMlst1tmuNbUQ7mcX7toDwsLReBnk5NCkIuC5vn1LG62m76UmtN+Tge4X0Bc8sO/WqEQuXBnkVleuvNtx6dpfOq5d/aOVz+QC7r54H/tGCk01u1Blf/xGaxf2zqTwCYBmSDfaIEiJon6FNQwYaXj3Wgn385PcjCvEeMI11LtA4QUSPMgferMdJsZSO3lNQ1vzQQU/8DzuMTRF/S2G03CJzKmOYQ+qCP4nWVw/O0iiOfypqmj49eeHeQDTR/yOQhDO+dJ5d3FOGdcpX6I3bqiAJusfEYtDxN0JY9DRkhawNriJkzkh9PNDJwLtmAakKI3XyuGd+92qVI/MvN4uC4KmQ+CMQsEDkwfLIW8tlZrrSJiCuKDfw5oGenx6iQuJrB/xwL/fRLhyirD6ya0mGATkedgTkJZ0hgcPSl3AybEtS22UN0HH9LS2LUIwV6nONkcAiDmTCqdzKpz5GScTuEFuwjQtMUitKmqK3mEMwV8gN9ASaSgdf6hlSEdKxErccGlqUr1RYw8KZxKK906x6lLX0IjIs/dvBBul2+lUU4NrwAm5SnOP/UYYFA/OESPZcuDWf+N1loYUSl3WhcmhtALzChTrYioyppRUCd6IbRXOWc0DB68uf6QcpTwjqtG6npRw9tuLCDlpSxN2G8grrl0VK6rbL/igpqzYHWdzo9NDXs1DXs32ID3k1WyOs4e8moe8mi86r6aZVmNuX9/+cTujTLtLaffwn6zTqCeoPiQ4PCQ4PCQ4PCQ43H+CAzAZ0Np2azDW+rVMJvf9unpZ99e0S/cQcNmqLnK7slw9+nEpAAIVQy05aUO0HQmLJgy7om60q6BwmwloxZOicOKS/rMopXXXxyX9kaepojAdVmLxL6uCdsRG6DE9lHre5/tEqlk5z+CGpw8bEKzueXoPJOUwFhu2NA2z5Hcr7GszT/P7NXEg7jhav1dZgW4DIhxS7Pt6is0XoNgL5JhlQ/KqR3SNSA03MMT2DJ2pdEHFtsOiwGqk0kankiK3Ti+eMOMgHfIY+AH6Bgy7nm1KcvwBKSkuqJ+sNIxLH0Y8sFzdIyXDgi+IBa8hp0uyszaaAPSQTt7g7ptHH36RkuEXLhZ+wTLhFyQQfsHS4GcvCjoeUtOiQ7jce+erjZtc9zI30423+6bDiDhz29l0O7E5+z3pKLDRNPdN4kOHliWoxIurJQasO6MOF5R2N4EtwEilZalLHeuuu9wlOzRdsUhAXCTsqKGkxDQfgxhri85rcK1BabNSV9NNkg1uFwMG4sJSwiUISTAZOdJcO9kb6v8o8gQvDz3SKqrIeZJUybWX79iSO+XjQVCabMyD4CA1f2LWnfmgm/r4URTqo4pqaniwI1Scjanni+JwXdlBjRU7e+uEHNZlcThOskO9tk9RolJOnNxCZqNQtaCOEtjCE0OtAf5pEc5NrmOZwNUcdnTobQK/WJsQ2hf58d6ctkbR6daQW+Wd6GEXIVV3aY5+1/4mpP5hBW9316WPSdts//jo+NnB0dODx08uj56fHj09fXIyfP70yX80GmBg26t4s0ztvmVf0hjB+cv2pf34xA/oIma8a4KjSRphKIgu+n7AyQdMgeS+lHCNhUuu6Hfh6OqxbWpZnZohnWIDwJ/HBdygZBLQORsChD6i6K9doLPSNh7Nufm7vxvoCYUBrjjsqNVr+l4TzWSuwMylrQrmZmts5uEMEHcYptwywqZuWX+9XLU/OV+tvGptcxvFbcN1vdBJGGGbXLwzF8l1zt17C4xexKsyUZHTLor6o+jNJrsFPVA2G5tIlHqJfn9MpwGVFmWjiDz2qHFiCUtOLwguXRBkaO5MR6YVVuzmA9ZYKeBfX1HUIQqn0IWicvEX0bWKGWkorevrnbNSsmAkWByOzErOqE9uoSpjh0EMWcs+pgDYtB4M3qcyQ9SV3hg1BhKGObBEoAPUBkGUJtSDSz+KXkAds+TGhVIZDlLbMemD+mNg1LUmwtxCnyxGAxZ5QpJCMkGa1BbgIEBYAogm1wn6swZojoL9qSjvRBnunVQ0GbBR0MDGSxNL4051Gg7Hw2gYj7bR/jdpgtHtUzlLTZoahpzTHueZ07fZVbDbYTkXmwXlyHMd6TpCPFKdwcSIAJFkEkA0MfYxiXIo1BQDTil8pKSeJQPn+ZK7iicmxBGlQI4wBVp1ugJjHZfLF+9NZx5imgZMhi1SCX4WBCVZQqUeLv7+VqIrH5W6ZL4Wl2FAC8uQJuGKLSYmtjmTVKFNly186O3zQ9OzUjcfJK4gMTCYY1RrXyoH2ClQjvbMeHtcsHhipD0XiqwBeKlrfNHPIv1rl2870UmzEinXGjFjKxtTuOsQhnThTRBSNylahYxoI3S43MavdRZZ9YJPurzdNZhFrS3FYYfE08vbeMB+dJ1KKk++4OEP9RL8ziasDQHXgp+B6WJOhcS8S7KU+sjNiYSfWUUFNSgsMQKPXSe4XMw7tlZHWKgqSD+z+UqaVxVmjgmGRekxpb1VBMuawo3HzEry1IAzpuiLp5Z29FhPxgkiDNSM1LANYFVFvijQ/Jkut9GZmJPvShxiGz43u+ONMVcH5zpqBjMfJ9M6r0sAnqiZ3jGizg2ipTRCO3kMQmTjcGPocnhcOoaK6GERZexC/HeLWSmj6FYI4VOFOr3JDmC6Hw3lC0ld9cW4DG8Gm1cY1xwlxureCO8fKkEzZLBGaM7DK4sySXV5aduuj+6ZpNnJ8b7Tuv5K+VxU/NxmxImzRRo50/lpmzWe+2HfvKg1kN2q1AxDw+M3Gkc9RLI9RLJtDdJDJNvmOHuIZHuIZNt9JNstA8n225FkOo7MUharnw03LcgH1yf4Bfz3mRU8GnftJwtA64p+u1vy2HvJGrvNxe7bxDbIQ+oFIqfCHb1LfChe+VC88qF4Jf57KF75RRWvlNIi9JxjQdNfrQl20oVJmvaYyv0NDU6tfkIoCwlw2E4owti1iNwr8m13QBMIb7EUedLUSXnZTJamEpeeG5/UMQObmwvUYqbmaKbZYbmNV3oOlz3lIgBq8B/BscHrnnqAY+SAoX8uohE7LSHIsoNGtwJT0gpF7iqpXjOSAen0Yb96tD61Rb/n4cnk6dHRxBdodnGc9tusWVe3q7OMDakMcXvJYpXgE5iajqFLD3WS5j8PP6DXocKajmUyZj+RIR0zNJGQk/rINJupFkF1tZnQNvsC9wmrQqgsIt9UWaJfguyCOFahYlyA9POy5nt2pJtxdWf4JObEfRvMQCqXJna2m8E81OlYeoS1djR+8o16qsYTdRSqZ9HJt988jsfq28nR8Tcn4fGzJ9+Mx88fn3wzWVei4P4bSGgKt7G0cv47wmldLcq8SAG2Qvt0G5HPw1R3wHIxpE/d5AY9Vp3SY+G4hlUUlvi0YIC/m8LprPFlnp8y8SpESEcKc9roenMbn6Rc7EzAw20EkoDNhTOK5Zyk4hTvLWbH5k4xOvQ3ld3ky1Z6bZWWxQZclEWW0ggNkCxuSqEGZLxKQyzBIz4kB820BMn91dc0y9t1ia4kVyti/8VfVViV7SFgUwA7oHyHsB6qCbQwblCDL6QtsUaaMWEPszzQY5juHx1lCN01HLhJp05UQLUTY4z0mKHxG3T6x4Srb3W66EXt2pTEcpaPO+5Zj0nijU5c0hEY9Ep6OCUNYpOC6dT50PnEOGhQhxnUVBwYeRvfVZ/S/d3bjt0Fmu//TQeI+htifCqezNPeFcvDqNpB/gGNUqEEb6uK25s3ZJ5rO2VoyK9dWmz4eOhWNmDXiyf+2W9WSH/81HpHnPbtEFRsCDj0K4/6IzketzW+NtdTJA63z9IjJL6tB4/QZ+IR4v0Qw5FbSKhlPfpkbiEG6cEt9OAWuh+QHtxCm+PswS304Bb6otxCXA/vS3MLCdTu5DtxC21+u+/GN9Sxzgff0INv6ME3hP8efENflG+oLphjiWHg559+pI/9VgF4Quvx0okyKOsFldTkhDecqCJwsBMG7iW8ItXy5EkT7g5gjkEB4dSJ/AZzCdAgHqHfZCDK0oDys+T9PNBsfhMLQJc2d3+H5qUo54LuIh2Yav17WOtYjFKgEOz5ZlnKmUG7LKZiIj7n4ZKDpCWIFyUCLu1HeOWgcgzw13myob+0QPJsyORLDRFKNZDoeltMmqTTaW7amogWL4aAljToL8HD66QIp/PddW7ax9vWsaxh97twUklpjtGfRg6iq3yx1zB2wgO6OYn0YmGBW4Bu8IwdppmfT/iqRPonk1Ayx/2UtBwKrMawebNbS8f2wuUbzLowrQXQQDf8CGO7FYX3V147Fsw1gKu2qMngiNTDkePa+OMbnlwxpqPbmL/9pycnTw7ZvPqvv/3FM7f+CbbAw2h3c6D7vKy42Q2tUfoDEYmUJh/JrLYtSoOGJBHp2Hm8VRx04NaCic3ppKKoejMHnF4Tlu72hBElvKHxm8fAV5NS0ol/xRq3JpRfl4ZFxtbbXMfkb5nXzLAh+TvRvqwBHXiMt9Pze6uNxdF6fm7I+WXp7OR97/l7Gb6zCaaFodqVgPSeGvp4czs8SBC01wCnpW1sl/7qaBytKWHT2umhJ0+8+SnNa1dnEPksTSD0auwWBC//wgUGOtdgSB7R16CrFjv/V2Ln6iMVAnbaOLizUKoKX6amp1aW47t0GB3DOFdtcmCnVytd0Smk+TCgQj81cCbjxXKohhnRdFOaLyoLD4HOT47k7YYDzvMwww/VDXAvMyolU93kLCc07iwWkHa1txc0ej+5EyPZa7BUToMdnXZevQxvD0tqyco7VmDdSAOHj7gQeBJxuT7T8FLE7ZarrLuQDz3KVxD1B1bXobmXRTjz3WffOYUwsPMbxQuRFdjVSfCbRJVyFLQuxw10YLaMXktinb6qpXeTcCuXIh0z8k0KlubbhFX9gSaQL8j68QUYPv5om8eDuWOtueOzs3R8tkYOeOoqnGrtx+Hsgf12A/7OY2gub+MyUZ+X6kK6eoW5WQS4S1TvpLTQLL+RNqRYykLHjVDYjFNvktYHtyhKC7UBVcsXm7Nk7ifxqU6yzNbckuT9TAcGfKouSQ6FMOpaQF2Ek7DweyDtWHf9OZMNvfZjhyxxdfjof0/SNDx8OjwKHjEa/yV48f5nQSmWRDt+fHXMjSp1jbSvg7MFvP2LGv+QVIfPjp5iO7Cnhp08+uH15RtQY+md71X0If86kGimw+PHMNGbfJyk6vD46avjk+eCJximWSL2oeh0J9QPRacfik7fDeL/tkWndwvq39pct+dqQC741VcHOMspSF/Ug0fEhr/yJ2/g/0Xvv9CWB2zfmWf0nol51HoCyZGplP2QCtFf9QQwEmiNvgldq/dgaTZDkAV6IyNkQww4/N2G6/HAYZoYuyYa1E5FFW08PE+mRcjzVUWt/NF5Ld6w+fhXFWl5lj9crV3J/zIXlsEsbZluNEXolLBQHwJqZu8BYGWk3kle4UuNapVUUiaOEynpg2I6BapKUD3NY4p7uXvoQuOEhPft4AqwLGhOzLW3kS3qaG8iEpH73Mr9o0E7ya49cCeNNkeXcxSleR3bg/QCP2ozBIWLh5Ix1oGJN/Iri8aR92qJWwRCleRmwIcreuBKD6mrsOWFe9S8NdMLQ3gOSdNq5oYhyC8HH1fTkCt5yitIL9/nOSbx0IplB/8UnCEyOQ0Jq4XaQ2MidwD8oQGMlrpmNzofXrnXzhw6rcRmxK2exqQkmee3nmkDAmvMtSkNO7NJds+VcwxXTyYvDJ0XNp1L2DwWu1tebcBcV7+16axCaZtuXIvKN52Hw+02msN7tIcfxBjMXliG8FJ/7jhc/Bvl3zSzKuQ3PNolWgqu+H7AsudpiagEwoHv9XwHhhn0XLsGLLtK9/bo4/JyY7gRKN1oclDV/UrndvRMNQcGvP1s+JZ7lLactfHmZpPefjo4GiotkWVevnv5DiWcG7TYzcMF8tlS/WsLFk/cwH8rRA78t+LqPUdcBQzCUFMu3neWbl/zp45BzlFecKhVrLD4uk46HDoESo3Wu8hTbgwsqunk0CQmKUZF5XA5T4fyHOdVh4VEIufZgX2zYWVl0C3muii9f2s8U6geYpznqQqzDdE7sRgh95vd9va8oMuM6yRtT9neUXNx7x0/f3l89O3eZuCA8kcz+J1LZNc/1GPUgjkRRfb+B/e7joHt70bA8aUVO2jg7vxqTmZfWsvNPKBX73MT3Ys87j7qWx0gBwMwIJv9OqeqO/jmbWd6DzP9fP6yPREFzC/C6P4WZUdsT4aR7PeKwUzbitqTMYtazwo3m0h4LvDY9kzkm+ASkfc1nTNk95yFoly0UlX3i1A7bg9aY3ggX1Lg2L1ObMftmZhSjSd1eu9LdgbumXrNTX/bic2wa6ftFmvuPi+PK+zc9rVodbXoGFfXQzdc3ChsXVzX7ZmxDctVHzcVrHRh8VabBPzXI3CHi7ld7fdcphY7WHevWEcdsDEL68aGRZLXJfW81lVrVy4/L9q2iRX2nvf6LbeUQXtIN4xxizHdkApbQX+ORVTmi603qm6zPieSC/9RHuBpcLwZuV5qSLT1gO2DWFs9wXIvGNqJXcQT7MH+M6YCq0UezRrr0dHcW1i9zmzk4M8YwkzRTDoCm8Qy9EdzpKIMtITJkshKJS6e9LidoUo9G7YSM5dsSaEq1q2YJDWcDiUg6XTPFqkI8mtV3BQJhiV5ykVH0O9tYcIhBrrmzJLtYAdhWar5OJXA0Q5oTRSmyKdDQP6aEMwtltUICr/dwmYN+3ED2Q7g22DciYYMug/MGhLoCockiJxYyE3gsEGit0XQoisYlJHjR4JuBJAbpnlbiFbGWzJk7SDLjSFsRPvfBkjgMnoUKWZBDQhs6i3X4TedPTTUHN2/GlKjysLJ6md+2/As3wl1201BeJp+ftmUAPRj9gTnHMJveh5suCUyThNCd8Ud63MGAClmlsfeFvXJWCv31VkqD7ntSlcs1t1bGAUw2QFvS98A1T9DaSj293rDlUQY4oXZ02jZ0NPqNUmWAPzw+vLyfSvEx9kd7H5Qtm69tdvjiv516aZaO6M0xIyVa6L6fTQYRxnIQgR8htLbjP69sM69LClnntmn1+6zErafS2sEeYvAcXxThQ62mMqOYJqFSefQ4AZpMoF9WkYpha6SB47iXPOI+gHFW66ng7T6KKufsNbtwXZkpffFY2+eer/aqXq1CItw7gmtK+2fjZ+b+9j4uYRlqPhqkuahix38GrstTULsf4Q+ePrX5L+0C917sxKVcIGkIRpNFwu55Gq3poOYK1h4rTiRR9YRmDIL0kaogVi/stWGV0cPlBdercx1fuG7K9fn8znrfjpM0xXcdGChmifcSaubAfcekd7r8DaQ9hTLuitsKrtOijzzxZPbwKd3zhmwwwCdhtm07jJNNFj7qqu3seu3vngbrmbs+kexoxpGjhjugqC9obcGorGtm8BhbklQj5OOA/AHo1LA+iOw1zO1I4fPFaYrfm4oM4D9EUjrnNzYd2zjqtupBs113NVFQcUYLVBOm0fnQiIF+45+tUs7iRQbRXfgvoy9z2XnJNmH4tHcADXr+uoZBxO8ZCgeCb+g1KRyAc9zVCu1jGov7+7RUD/ww0gBpjKipFFFSsuLcsPsl7qI3iMFGuW+CO37g2B/HEYfptQH8dd8DF+oKvr6qxb9bCsZ7IpyiHTOX2qyJ8hQWLblttliCHIQKAhYVLJpQKU+qp/lYqTFa5eF1uZn3Fqtv0d5y+V6rK4w07FP/CHS1BagtHwt2+FWh+413l4dttnstSeREOhJ6cmQNMe6CWZg8ib9uqM+z2qVlbfJXeS6obLJZkQzh5S7xV6mchU3Jfh7PwiSieZ4qDbfwg08+6v28Ifm6z1gdkUwFArtsay/E/GVm5y9Xfnd1+DLA8M3qawWn9yJe+WnVXLMGkmmx7PvP7J2SYu8TYMbCISfYEkm3GPDFblA+cEg9weTDgzZAKSNYqS2sQ68W0gYObnVum0Ere2lPsmViqq6uOPZwTvXHU3fHgSNFSBuwlK61lJp+a0ut0Yc+B0AbXqh7hHIZNECz/tqlb3lfQMeLOLPHX7dtP0tgMk9PzJzZptRc0EZNc4TXpLqRoh8Z0IGdeJx95WbN9J4hi0kbcMmGwlT/kC3OsPkedNpUVsTRe9ebBqwsc05P3cQvOCuusYFYhtQyIxcS6inavwaMTwspi75dKdLrcL7CpzreBeYo557HYr53xuuisSdTbigCbrpMeGhVFmZVMm1r0pucyoWHXJVw++xSkqvqWK0wbBVNLTZUQfPbAXT/QDVAgY0VNF/bgMVsYy77fSFjxQecmMpVCpQbalH9F53WP9e4fm425rOpOidxjM3PTeDE3e4y+3V7btYA1QzMw/jqC6aHSC31xNvBYud2ybD9sFwNQ9/zYsWJFiSf7PJ3uD7xhcuzhhBgqGfNmlvZii61fLJDgcDsvUK6+0pcj2OwsX8gAEaNWOryjsSeae83b2qXsh1JQqXjNJ8OuU63241jF7e0aG6bgnEeauj1y1BcKsFbQ3FK6oEdBsAbM5fYo1L3aryupyIDpmyJVH249FIk5TWagUBif2z0swAR5Wi7hwmI3VGhh5tYO8xrAlItgSnUtq/H3yXFzchjoR/iQN6gLNb8+EkKUCa4rJSYl+xAJpCi34XVjMteVaBgd4o5CjzZDqryCYMJytTeKuEBZZxgD0ADgzL4QqGUhANZISp2BKCeZgmEUWZbrGRjfouq+0ejbIvvftzh6IvTrUXM9y9VH3pJctuJthDrI0iJ1ufvM2rmLh5OPdYyOQuBUz21jGngGsfXfVmc4N83bqWGl+ukraALG6oewRtLLpWsJEgHoRZmE64IwVichCgw8I77cEhIgWoZdyoeL9iOVvcOn03aVfBnJWrdM3kbpGFBkhNEWc7qHpnX1vloVXnwQeLSxg1oPKV3z6YdCKrP0L/BeAoiqCdR3Cv6/owfh2luzs1+qWkrhpNa+AOOks+NblMeRcU9tpAVopGdzd2rHCdd9o0+i0a/RhfY+u4azWYxrZKTZiOBW0RC7BqMQ6LF669d4fFIlA9xXo6luAH8t/fErjk0t3XsUnRp65luVW17mdhXAZrmxXdqvhWx2K2i/3YbDVSO+guG9RbpqhjCV6trftZQatC1l3Wsq46lyctcwdjtOmFfraQF0/aFbHpAXVmy+K4I+oGlhiKsLTeaTQf2iy0A12gR7g9WiNeyVfeJPTlgXEHc+IdHB9OWuusQEsm+AQbZVeNklGx7Q3rTYK5Vw2DDGpeKsEK8G4wO+lmFHB8E0pXImRU91F86CU1CAsX6JhhVSgTCdGUf6eMiUyUQ452FtExdGKIvaa021rfNnJnS2LbifPVGkuLtXkSbrYycEZ1uoDfN4er1zfynW4tinq0KL64jxy4mMxDUHyBjheqgsOV207tfi3mthp1tTmIK/D0ggdpn5wB2aaSiS75jxnLYaT1ZZkbRi6IXEmH5LgIjJVGgqjC6AOHC1GsXNmxFCJQjN4GkO+2jO9lpB/UsmGb4YODhxebXgDV6knXwHPltK+9NVi/mIYZygyOI4i7Zb6oK5uN4LABaqJB1ZRib1PWwdxnvd3cX9DQYgzQYToFwqtmcxtIw/BrIcNd3iow7+6nfQHz5mVSqRaQiFlMG80J6bpem9JVrmwxeS8W3IZeD2AERSPRkHCdUTn5EDNh6aWkEczvrnAGM985fvKcc1DywvRaoaJ9HyO14FhIl6GBjo49rsMazV7Y3YdNebqROvUzbNsR7hwH6RK15MHkFGWVWUAHlGQ3FUsi0vM4p25KJaZyykN72KEzn+7hz3v4+14HvObpFtBNx/BKtHaqY8JJ0UI4K7APVOueWBnB0kyUWoHOFnyXBnkkkiOvpbQjB6nIRzO0WlJSIsogGEDmvDXsB+sKE5ngxC7vAp8egyJeqb8PHSYLQCmwYSLeKmAydkJ2wtLMK1uDKCAg3bwUcTbhLBtycTpwURfzHojmaBaedm9cw56G/zqu904YjamawZBZVsNw5VSb33qH2rEKr7GmfNNy7oEzCHIJBgKaZ5sypoYNCI+/5hwwwNUl8d5Zs4g8rtM7HQAewYbUdQPfM32Dka2b3IiPvYNdzRLQb4podqdDEyfYGDSSGqtkV/U5uGbPM2oyB2JSQXco5ZDR4kEmKOYYYmJeGoJcUOn2CR2/ryL39rVkl9SROhlsRvHte8pXUlZdSrpVVM9OzAoVxk0xYattcK2EPBxDZ9DF4nfBnf8GKGrN88r1XNlHwwp02TGgvBmI0oD39nGDTnD9rmGVUIt7Q64J3dg5xD3oXXd5OLH+OwfVKjxXpPBc0Tm69Z1nwxVampR06TN1/Vypi/oVcksolITh1s6ikJdEr5LIGBc56mgbLqS8yjFdsoeH3HUtMran5rRXth5Uo3xegdyUJltxPA9gLd6GVp8lOpFhuVfmksE34rghEwvRMHgHXFlr0VY3JuNJqbO6t1kYsdd7IyuN//4VeCiIqEWkONctLu60Srglyvs7JzRayRcr79MmJESPX5FC2bQWbwpGWxJ7L8O1sbvq9j8ydN8lA7QmcRHvTcJSB89BuS9KzhhZDzFLHzNS8sgEWLaULtDK7qJunfmly43mpQtQomlG2w22U7ko1vQu4lmJkSJJZaxErKD2nUJ5+NbE2T2bq8R4BjQyW+EKh8HrZIpMiB/jDCbqqEYj9knkZPIq7nS905FqRnlhFyouhN1Otf0cLkAA8v+7qw/W9Jlfemjf+VKvOx/2nV90O7I52BYR6dK3d29mfqjgtKRhdWtm0Z0uoUftNMQbQ0QVflCZF3sWzq9Wg+09c38wn/HAqqK60gZ1wSs0be2/MOQQ5VmGeQZwFv6p3G/fx5esZmDqhTGdIGmUFQb7ojMvKSgIETvKm47b8DVckyKseCscUD9YkgzI9YJWVrwLtCemDYFu8g7CRy1VbVujIkS6FSy3ZRR7T5VPiQeYutOljoARb+keOSjY8/sGsRWV6DzuwK48JCWg5vyshM1h6B1iYK7maFqshRp0in+jxn5f1Ixfp1p8xV1+2dIN49nYLTri10zXxJIqxqXa7y0r6kmiM5LlojYDdolQvWQ5gjdbUyPeqA1BO2ioeU6qvArTIZr3houo7R/sqaTEctop+iIjv67NGjervIC0BSce4aRyIuXCcSlq9ZsLiRJXCMuqqyybE9eblI6NUo9kOTrOhI0eS6KnpAqKEN3v3mhEREdI68dHR//UChZjIrzlLvHLrY0Swt5mr1pb1Lja9NZg6kO54cbguAJLuxhGGAGDaE+7jYhPI9hTjNpFFlNoTpcwb7+xZWVUVxRY61LvW/sati7w4SwaSAx2xUGGwTlFucNmRXVKEiJl5msD67sLvOuDH5Os/kjh4sBHsZ+2Lf1ixmxMukix4l0YzYQmxzW22i5puHcX/46DUbOBsp6zSGKBI4l1hnZSDM291t/Tq79wmNNA3qcbo3n95ZpnDeVFHHzUIng/33Bbipe3HZJfNMpTD+hUGo7vMPoGz1zR0MRhm7cgzE2Z5yra7GWga1joKia6hl7vn5HeNyttM9Mm2lpnYoPNe0Pv2FAr3KWECsYiOrpyExeFmiQfQRz5T0L/f+1ttKUlLHyH7IbkXmK510nhckZ3z2ahtxBTkQzW157u/uH7SZXUTpmcSReAjyErEXPUNpAKOkDGeK9FwilOVM5Innn009mbr4duaJ3RiKzASPKi87UHofkBc3kVKlDAHmZU2AkEXqcr24dkHGYh7ylPcsXZv2afD6w6NnTB6JQHnd8Ja9vlX7XIyqvi2P3ums3pLt7YNak78V1sPN1qm2v10bExFIhmVWO3yFMXUF0ht3eCS+OmP1d0XKN7EylC+TW2bxPZ8qMuC63RYFZOZXzwWhZFDefT8ZlkAaj8w7Agc4t7Dvgb/wjAd91Bpn0NDq4TdcO1zjRdCjuwFfipJKnR7IFN/g3fwalAW/OPwsLYhO6aPt0fwBlsrkc4KeoAmbbxhVhL6SOwB3Qdx+1o6S0TUrZrj/A9x0jjI8k0M7QRMoS65FsEGip/7dTYu33o1XYg3roq3WmwH4+Hi7yspnDx/5YOqZw7VqjTxDNUBRao2yeJVirVdSyrrMc7WdlZMKkLskrCDAdxcp24kYmURv2Iwo/sGgBGt9z81x11bdyqjPcHKxn7Nfo/JBnth5QHgftMbwOsg+A2wdVWGCOK4vXQRgy0valjEeQ2WnvnrLo+6qZs12tS7cDDGlzgv3eTCVpKm2zTOR/7pW2DEiSc2r3UTiFaoMsNBs2WJB10GNfcfGZjxPwhmHkpUG67unKZRUFrabcJLlWlF7RDhIe2fm7pgzwWppoVeZbXZbrEaJ3Q+8ZPqfBrnTo33qX3g2+7tj9tlWRx73VVN7w0ujNzPcjaRvxtM3R77xq3cGv7ygFFEHv8aomNy41+/+oyOMQM1PLwNIn3u7j2xqfFBfn+D9E6wZR0qtg7M1glwKJkk7MDvBZ28G6y4SW5b3Ecpxaquey5hr7b8wApGb884AqQsft4F4zzsPiwQV+7dR1FO9Kn1izszC9p75S6lyB41AwROEZ0mib9iKbnhn8e/nnLhdyquH97vR1cE5hby4W4/XUpXuNbEbU1DVhNW8aTssXs8vXJevjV/wM2GQLI"
 }
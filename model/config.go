@@ -17,6 +17,449 @@
 
 package model
 
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
 type Config struct {
 	Experimental bool
+
+	// MaxExceptionCauseDepth limits how many levels of chained exceptions
+	// are decoded for an error. Zero means the default limit of 32.
+	MaxExceptionCauseDepth int
+
+	// DisableGroupingKey skips computing error grouping keys, so errors are
+	// emitted without error.grouping_key, also dropping keys sent by agents.
+	DisableGroupingKey bool
+
+	// GroupingKeyHash selects the hash algorithm used for computing error
+	// grouping keys: "md5" (default), "sha1" or "sha256". Any other
+	// algorithm is rejected by Validate.
+	GroupingKeyHash string
+
+	// GroupingKeyEncoding selects the encoding of computed error grouping
+	// keys: "hex" (default) or "base64", which is shorter. Grouping keys
+	// sent by agents are emitted as sent.
+	GroupingKeyEncoding string
+
+	// GroupingKeyIgnoreLineno excludes stacktrace line numbers from error
+	// grouping keys, keeping groups stable across minor code changes.
+	GroupingKeyIgnoreLineno bool
+
+	// GroupingKeyPreferRootCause computes error grouping keys from the root
+	// cause of a chained exception only, rather than from the whole chain.
+	GroupingKeyPreferRootCause bool
+
+	// GroupingKeyExcludeLibraryFrames leaves library frames out of error
+	// grouping keys, in addition to frames marked exclude_from_grouping.
+	GroupingKeyExcludeLibraryFrames bool
+
+	// GroupingKeyIncludeLibraryFlag adds whether a frame is a library frame
+	// to error grouping keys, separating third party from application crashes.
+	GroupingKeyIncludeLibraryFlag bool
+
+	// GroupingKeyIncludeService adds the service name to error grouping
+	// keys, so identical errors from different services are kept apart.
+	GroupingKeyIncludeService bool
+
+	// GroupingKeyVersion is mixed into computed error grouping keys, so keys
+	// computed by different grouping algorithms never collide. Versions zero
+	// and one leave keys unchanged. When set, the version is emitted as
+	// error.grouping_key_version.
+	GroupingKeyVersion int
+
+	// GroupingKeyNormalizePaths converts backslashes in stacktrace filenames
+	// to forward slashes for error grouping keys, so errors from Windows and
+	// Unix builds of the same code are grouped together.
+	GroupingKeyNormalizePaths bool
+
+	// GroupingKeyFilenameBasename only uses the last element of stacktrace
+	// filenames for error grouping keys, so build specific directory
+	// prefixes, e.g. of CI workspaces, do not split groups. Backslashes are
+	// treated as separators.
+	GroupingKeyFilenameBasename bool
+
+	// GroupingKeyIncludeStatusClass adds the class of the HTTP response
+	// status code, e.g. "4xx", to error grouping keys, so client and server
+	// failures with the same exception are kept apart.
+	GroupingKeyIncludeStatusClass bool
+
+	// GroupingKeyIncludeModule prepends the normalized module of the grouped
+	// exception to error grouping keys, so errors are grouped per module.
+	// Modules are compared case-insensitively, with slashes, backslashes and
+	// "::" treated like "." separators.
+	GroupingKeyIncludeModule bool
+
+	// GroupingKeyNormalizeMessage masks variable parts of the message, such
+	// as numbers, UUIDs and hex values, when grouping errors by message for
+	// lack of an exception type, param_message or stacktrace. The emitted
+	// message is left untouched.
+	GroupingKeyNormalizeMessage bool
+
+	// ExceptionMessageHash selects the hash algorithm for emitting
+	// error.exception.message_hash, allowing to join errors by message
+	// without exposing it: "md5", "sha1" or "sha256". Empty means no hash.
+	// Any other algorithm is rejected by Validate.
+	ExceptionMessageHash string
+
+	// ExceptionMessageHashNormalize masks variable parts of the message
+	// before hashing it, as configured for grouping keys.
+	ExceptionMessageHashNormalize bool
+
+	// GroupingKeyMessageRules replaces DefaultGroupingKeyMessageRules for
+	// normalizing messages, see GroupingKeyNormalizeMessage.
+	GroupingKeyMessageRules []RedactionRule
+
+	// QualifyExceptionType prefixes an exception's type with its module, as
+	// in "module.Type", unless the type is already qualified that way. The
+	// qualified type is used for error.exception.type and the grouping key.
+	QualifyExceptionType bool
+
+	// RequireTraceId rejects errors referring to a transaction_id without
+	// also carrying a trace_id. Older agents may not send trace_id, so this
+	// is off by default.
+	RequireTraceId bool
+
+	// OmitStacktraces leaves stacktraces out of transformed error documents
+	// to save CPU on high volume ingest. Grouping keys and frame counts are
+	// still derived from the decoded frames.
+	OmitStacktraces bool
+
+	// StacktraceMode "top_frame" emits only the topmost non-library frame of
+	// exception and log stacktraces, or the topmost frame if all of them are
+	// library frames. Grouping keys and frame counts are still derived from
+	// all decoded frames. Empty means all frames are emitted.
+	StacktraceMode string
+
+	// MaxTimestampPastSkew and MaxTimestampFutureSkew bound how far an
+	// error's timestamp may lie before or after the time it is decoded.
+	// Zero disables the respective check.
+	MaxTimestampPastSkew   time.Duration
+	MaxTimestampFutureSkew time.Duration
+
+	// ClampTimestamps replaces out of bounds timestamps with the request
+	// time instead of rejecting the error.
+	ClampTimestamps bool
+
+	// TimestampFallback keeps errors with a malformed timestamp, using the
+	// request time instead of rejecting them.
+	TimestampFallback bool
+
+	// TimestampDetectSeconds interprets numeric timestamps too small to be
+	// epoch microseconds as seconds since the epoch, possibly with a
+	// fractional part, as sent by some agents.
+	TimestampDetectSeconds bool
+
+	// MaxCustomSize limits the JSON encoded size in bytes of custom context
+	// data. Larger custom data is dropped. Zero means no limit.
+	MaxCustomSize int
+
+	// CustomKeysAllowlist restricts the custom context emitted with errors
+	// to the given top-level keys, dropping all others. Nil keeps all keys.
+	CustomKeysAllowlist []string
+
+	// CompressedValueKey marks compressed values of custom context and
+	// exception attributes: objects holding only this key, mapped to base64
+	// encoded gzip compressed JSON, are replaced by the decompressed value.
+	// Values failing to decompress are kept as sent. Empty disables
+	// decompression.
+	CompressedValueKey string
+
+	// MaxDecompressedSize limits the total size in bytes of the values
+	// decompressed per event, guarding against decompression bombs. Values
+	// exceeding the remaining size are kept compressed. Zero means 1MiB.
+	MaxDecompressedSize int
+
+	// ErrorEventType overrides the processor.event value of error documents,
+	// e.g. for routing them per tenant. Empty means "error".
+	ErrorEventType string
+
+	// EmitLogMessageTemplate adds the log param_message as
+	// error.log.message_template, for aggregating log messages by template.
+	EmitLogMessageTemplate bool
+
+	// RequireExceptionOrLog rejects errors carrying neither an exception
+	// nor a log.
+	RequireExceptionOrLog bool
+
+	// MaxCulpritLength truncates culprits derived from stacktrace frames to
+	// the given number of characters, including a trailing ellipsis.
+	// Culprits sent by agents are not truncated. Zero means no limit.
+	MaxCulpritLength int
+
+	// CulpritTemplate formats culprits derived from stacktrace frames, given
+	// the frame's {{.Filename}}, {{.Function}} and {{.Lineno}}, e.g.
+	// "{{.Function}} ({{.Filename}}:{{.Lineno}})". Nil means the format
+	// "filename in function".
+	CulpritTemplate *template.Template
+
+	// DropInvalidExceptionAttributes drops exception attributes that are not
+	// an object instead of rejecting the error.
+	DropInvalidExceptionAttributes bool
+
+	// DefaultTransactionSampled marks errors as belonging to a sampled
+	// transaction when a transaction id is given but sampled is not.
+	DefaultTransactionSampled bool
+
+	// DecodeLogsArray decodes the log records sent in a `logs` array, in
+	// addition to the single `log` object.
+	DecodeLogsArray bool
+
+	// SynthesizeId sets error.id for errors sent without an id: "random"
+	// generates a UUIDv4, "deterministic" derives a UUIDv5 from grouping key
+	// and timestamp. Empty leaves the id unset.
+	SynthesizeId string
+
+	// RedactionRules are applied in order to exception and log messages
+	// and to the culprit before they are emitted. Grouping keys are
+	// computed from the unredacted messages.
+	RedactionRules []RedactionRule
+
+	// MaxStacktraceFrames truncates exception and log stacktraces to their
+	// top frames when decoding. Zero means no limit.
+	MaxStacktraceFrames int
+
+	// CollapseRepeatedFrames drops frames identical to their preceding frame
+	// from exception and log stacktraces when decoding, collapsing runs of
+	// frames, e.g. of recursive calls, into one. Frames are collapsed before
+	// the stacktrace is truncated to MaxStacktraceFrames.
+	CollapseRepeatedFrames bool
+
+	// DropEmptyFrames drops stacktrace frames with neither a filename nor a
+	// function when decoding. Such frames do not contribute to grouping keys.
+	DropEmptyFrames bool
+
+	// EmitNumericExceptionCode additionally emits whole-number exception
+	// codes as error.exception.code_numeric, enabling range queries.
+	EmitNumericExceptionCode bool
+
+	// ContextFieldsInclude restricts the optional context blocks emitted
+	// with errors to the given ones, out of "user", "client", "user_agent",
+	// "http", "url", "error.page" and "error.custom". Empty means all.
+	ContextFieldsInclude []string
+
+	// ContextFieldsExclude removes the given document paths of context
+	// fields from errors, e.g. "url.query" or "error.page". Note that the
+	// query is still part of url.full and url.original.
+	ContextFieldsExclude []string
+
+	// LenientContextDecoding keeps errors with a malformed context, emitting
+	// the context blocks decoded before the failure only.
+	LenientContextDecoding bool
+
+	// OmitEmptyExceptionMessage omits exception messages that are empty or
+	// consist of whitespace only.
+	OmitEmptyExceptionMessage bool
+
+	// SubstituteEmptyExceptionMessage replaces exception messages that are
+	// empty or consist of whitespace only with the exception type. It takes
+	// precedence over OmitEmptyExceptionMessage for exceptions with a type.
+	SubstituteEmptyExceptionMessage bool
+
+	// PromoteLabels copies the values of the given labels to top-level
+	// document fields, mapping label names to dotted field paths. Labels
+	// colliding with existing fields are not promoted.
+	PromoteLabels map[string]string
+
+	// GroupingNameComponents lists the document fields, e.g.
+	// "service.environment" and "error.grouping_key", whose values are
+	// joined with ":" to error.grouping_name, a composite grouping key that
+	// keeps groups apart across the values of the other fields. Missing
+	// fields contribute empty values. Empty means no grouping name.
+	GroupingNameComponents []string
+
+	// IncrementalGroupingKey collects the stacktrace frame values the
+	// grouping key is computed from frame by frame while decoding, so the
+	// decoded frames are not needed for grouping. As sourcemaps and library
+	// frame or exclude_from_grouping patterns are only applied when
+	// transforming, the collected values are ignored if any of them is
+	// configured, and the key is computed from the transformed frames.
+	IncrementalGroupingKey bool
+
+	// TraceIdValidation checks that trace_id and parent_id are hex strings
+	// of 32 and 16 characters: "lenient" drops malformed ids, "strict"
+	// rejects errors carrying them. Empty means ids are not validated.
+	TraceIdValidation string
+
+	// SampleRate keeps one out of every SampleRate errors per grouping key,
+	// dropping the others on transformation. Errors without any content to
+	// group by are always kept. Values below 2 keep all errors.
+	SampleRate int
+
+	// GroupingKeyCardinalityWindow is the time window distinct grouping keys
+	// are estimated for, published as grouping_key_cardinality once a
+	// window is complete. Windows without errors are published as zero.
+	// Zero disables the estimation.
+	GroupingKeyCardinalityWindow time.Duration
+
+	// MaxLabels limits the number of labels emitted with errors, including
+	// metadata labels. Labels beyond the limit are dropped in order of their
+	// sorted keys. Zero means no limit.
+	MaxLabels int
+
+	// EmitExceptionTypeHierarchy emits the distinct types of all exceptions
+	// in the chain as error.exception.type_hierarchy, set on the outermost
+	// exception.
+	EmitExceptionTypeHierarchy bool
+
+	// LenientBoolDecoding accepts the strings "true", "false", "1" and "0"
+	// for exception.handled and transaction.sampled, as sent by some agents
+	// instead of booleans.
+	LenientBoolDecoding bool
+
+	// RecordGroupingComponents records the values fed into the hash when
+	// computing an error's grouping key, for debugging unexpected grouping.
+	// See Event.GroupingComponents.
+	RecordGroupingComponents bool
+
+	// OmitEventIngested omits event.ingested, which is set to the time the
+	// request was received and can be compared with the agent's timestamp
+	// to determine ingest lag.
+	OmitEventIngested bool
+
+	// TimestampResolution truncates the timestamps emitted with errors to
+	// "second", "millisecond" or "microsecond". Empty means microsecond.
+	TimestampResolution string
+
+	// GroupingMode selects the values error grouping keys are computed from:
+	// "stacktrace" uses the exception types, the log param_message and the
+	// stacktrace frames, falling back to the message. "message" uses the
+	// normalized message only and "type_message" the exception types and the
+	// normalized message, ignoring stacktraces. Both message modes normalize
+	// the message regardless of GroupingKeyNormalizeMessage. Empty means
+	// "stacktrace".
+	GroupingMode string
+
+	// GroupingKeyCollapseRepeatedFrames hashes runs of consecutive frames
+	// contributing the same values to grouping keys only once, so e.g.
+	// different recursion depths are grouped together. Emitted stacktraces
+	// are not changed, see CollapseRepeatedFrames for that.
+	GroupingKeyCollapseRepeatedFrames bool
+
+	// SkipErrorSchemaValidation skips validating errors against the JSON
+	// schema in the intake pipeline, relying on the checks done when
+	// decoding them. Only meant for trusted agents, as errors violating the
+	// schema's constraints, e.g. on the length of fields, are accepted.
+	SkipErrorSchemaValidation bool
+
+	// SplitExceptionChain emits one document per exception of a chain
+	// instead of a single document holding the chain as an array. The
+	// documents share all other fields, e.g. the error id and grouping key,
+	// and hold their exception's position in the chain.
+	SplitExceptionChain bool
+
+	// PromoteExceptionAttributes moves exception attributes to typed
+	// fields of the exception, mapping attribute keys to one of the fields
+	// "thread_name", "thread_id", "process_name" and "process_id", e.g.
+	// {"threadName": "thread_name"}. Attributes not convertible to the
+	// field's type are kept, as are the ones mapped to other fields.
+	PromoteExceptionAttributes map[string]string
+
+	// EmitLogSeverity emits error.log.severity, the numeric severity of the
+	// log level as given by LogSeverities. Levels are matched case
+	// insensitively, unknown levels are not emitted as severity.
+	EmitLogSeverity bool
+
+	// LogSeverities maps lower case log levels to numeric severities. Nil
+	// means DefaultLogSeverities.
+	LogSeverities map[string]int
+}
+
+// RedactionRule replaces all matches of Pattern with Replacement, which may
+// refer to submatches as in regexp.ReplaceAllString.
+type RedactionRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// DefaultGroupingKeyMessageRules mask UUIDs, hex values and numbers in
+// messages grouped by.
+var DefaultGroupingKeyMessageRules = []RedactionRule{
+	{Pattern: regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`), Replacement: "<uuid>"},
+	{Pattern: regexp.MustCompile(`\b(?:0[xX][0-9a-fA-F]+|[0-9a-fA-F]{8,})\b`), Replacement: "<hex>"},
+	{Pattern: regexp.MustCompile(`[0-9]+`), Replacement: "<num>"},
+}
+
+// DefaultLogSeverities map common log levels to the severity numbers used by
+// OpenTelemetry.
+var DefaultLogSeverities = map[string]int{
+	"trace":   1,
+	"debug":   5,
+	"info":    9,
+	"warn":    13,
+	"warning": 13,
+	"error":   17,
+	"fatal":   21,
+}
+
+// Validate checks the values of options selecting among a fixed set of
+// choices, such as hash algorithms. It is to be called once when the
+// configuration is built, as decoding does not check the configuration.
+func (c Config) Validate() error {
+	if c.GroupingKeyHash != "" && !isHashAlgorithm(c.GroupingKeyHash) {
+		return fmt.Errorf("unknown grouping key hash algorithm %q", c.GroupingKeyHash)
+	}
+	if c.ExceptionMessageHash != "" && !isHashAlgorithm(c.ExceptionMessageHash) {
+		return fmt.Errorf("unknown exception message hash algorithm %q", c.ExceptionMessageHash)
+	}
+	return nil
+}
+
+// isHashAlgorithm reports whether name is a supported hash algorithm.
+func isHashAlgorithm(name string) bool {
+	switch name {
+	case "md5", "sha1", "sha256":
+		return true
+	}
+	return false
+}
+
+// LogSeverity returns the numeric severity of the given log level, or nil if
+// the level is unknown or severities are not to be emitted.
+func (c Config) LogSeverity(level *string) *int {
+	if !c.EmitLogSeverity || level == nil {
+		return nil
+	}
+	severities := c.LogSeverities
+	if severities == nil {
+		severities = DefaultLogSeverities
+	}
+	severity, ok := severities[strings.ToLower(*level)]
+	if !ok {
+		return nil
+	}
+	return &severity
+}
+
+// NormalizeGroupingMessage applies the configured message normalization
+// rules to s, if message normalization is enabled for grouping keys.
+func (c Config) NormalizeGroupingMessage(s string) string {
+	if !c.GroupingKeyNormalizeMessage {
+		return s
+	}
+	return c.NormalizeMessage(s)
+}
+
+// NormalizeMessage applies the configured message normalization rules to s.
+func (c Config) NormalizeMessage(s string) string {
+	rules := c.GroupingKeyMessageRules
+	if rules == nil {
+		rules = DefaultGroupingKeyMessageRules
+	}
+	for _, rule := range rules {
+		s = rule.Pattern.ReplaceAllString(s, rule.Replacement)
+	}
+	return s
+}
+
+// Redact applies the configured redaction rules to s.
+func (c Config) Redact(s string) string {
+	for _, rule := range c.RedactionRules {
+		s = rule.Pattern.ReplaceAllString(s, rule.Replacement)
+	}
+	return s
 }
@@ -87,19 +87,30 @@ type Resp struct {
 }
 
 func DecodeContext(input interface{}, cfg Config, err error) (*Context, error) {
+	return DecodeContextAt(input, "", cfg, err)
+}
+
+// DecodeContextAt decodes the context of the event found at the given path
+// within the payload, e.g. "error". If path is not empty, decoding errors
+// name the offending field.
+func DecodeContextAt(input interface{}, path string, cfg Config, err error) (*Context, error) {
 	if input == nil || err != nil {
 		return nil, err
 	}
 	raw, ok := input.(map[string]interface{})
 	if !ok {
+		if path != "" {
+			return nil, utility.NewFieldError(path, "object", input)
+		}
 		return nil, errors.New("invalid type for fetching Context fields")
 	}
 
-	decoder := utility.ManualDecoder{}
+	decoder := utility.ManualDecoder{Prefix: path}
 	ctxInp := decoder.MapStr(raw, "context")
 	if ctxInp == nil {
 		return &Context{}, decoder.Err
 	}
+	path = utility.FieldPath(path, "context")
 
 	userInp := decoder.Interface(ctxInp, "user")
 	serviceInp := decoder.Interface(ctxInp, "service")
@@ -108,13 +119,13 @@ func DecodeContext(input interface{}, cfg Config, err error) (*Context, error) {
 		experimental = decoder.Interface(ctxInp, "experimental")
 	}
 	err = decoder.Err
-	http, err := decodeHttp(ctxInp, err)
-	url, err := decodeUrl(ctxInp, err)
-	labels, err := decodeLabels(ctxInp, err)
-	custom, err := decodeCustom(ctxInp, err)
-	page, err := decodePage(ctxInp, err)
-	service, err := metadata.DecodeService(serviceInp, err)
-	user, err := metadata.DecodeUser(userInp, err)
+	http, err := decodeHttp(ctxInp, path, err)
+	url, err := decodeUrl(ctxInp, path, err)
+	labels, err := decodeLabels(ctxInp, path, err)
+	custom, err := decodeCustom(ctxInp, path, err)
+	page, err := decodePage(ctxInp, path, err)
+	service, err := metadata.DecodeServiceAt(serviceInp, utility.FieldPath(path, "service"), err)
+	user, err := metadata.DecodeUserAt(userInp, utility.FieldPath(path, "user"), err)
 	user = addUserAgent(user, http)
 
 	ctx := Context{
@@ -201,18 +212,19 @@ func (custom *Custom) Fields() common.MapStr {
 	return common.MapStr(*custom)
 }
 
-func decodeUrl(raw common.MapStr, err error) (*Url, error) {
+func decodeUrl(raw common.MapStr, path string, err error) (*Url, error) {
 	if err != nil {
 		return nil, err
 	}
 
-	decoder := utility.ManualDecoder{}
+	decoder := utility.ManualDecoder{Prefix: path}
 	req := decoder.MapStr(raw, "request")
 	if req == nil {
 		return nil, decoder.Err
 	}
 
-	inpUrl := decoder.MapStr(req, "url")
+	inpUrl := decoder.MapStr(raw, "url", "request")
+	decoder.Prefix = utility.FieldPath(path, "request.url")
 	url := Url{
 		Original: decoder.StringPtr(inpUrl, "raw"),
 		Full:     decoder.StringPtr(inpUrl, "full"),
@@ -238,14 +250,15 @@ func decodeUrl(raw common.MapStr, err error) (*Url, error) {
 	return &url, err
 }
 
-func decodeHttp(raw common.MapStr, err error) (*Http, error) {
+func decodeHttp(raw common.MapStr, path string, err error) (*Http, error) {
 	if err != nil {
 		return nil, err
 	}
 	var h *Http
-	decoder := utility.ManualDecoder{}
+	decoder := utility.ManualDecoder{Prefix: path}
 	inpReq := decoder.MapStr(raw, "request")
 	if inpReq != nil {
+		decoder.Prefix = utility.FieldPath(path, "request")
 		h = &Http{
 			Version: decoder.StringPtr(inpReq, "http_version"),
 			Request: &Req{
@@ -262,11 +275,13 @@ func decodeHttp(raw common.MapStr, err error) (*Http, error) {
 		}
 	}
 
+	decoder.Prefix = path
 	inpResp := decoder.MapStr(raw, "response")
 	if inpResp != nil {
 		if h == nil {
 			h = &Http{}
 		}
+		decoder.Prefix = utility.FieldPath(path, "response")
 		headers := decoder.Headers(inpResp)
 		h.Response = &Resp{
 			Finished:    decoder.BoolPtr(inpResp, "finished"),
@@ -278,7 +293,7 @@ func decodeHttp(raw common.MapStr, err error) (*Http, error) {
 	return h, decoder.Err
 }
 
-func decodePage(raw common.MapStr, err error) (*Page, error) {
+func decodePage(raw common.MapStr, path string, err error) (*Page, error) {
 	if err != nil {
 		return nil, err
 	}
@@ -286,18 +301,18 @@ func decodePage(raw common.MapStr, err error) (*Page, error) {
 	if !ok {
 		return nil, nil
 	}
-	decoder := utility.ManualDecoder{}
+	decoder := utility.ManualDecoder{Prefix: utility.FieldPath(path, "page")}
 	return &Page{
 		Url:     decoder.StringPtr(pageInput, "url"),
 		Referer: decoder.StringPtr(pageInput, "referer"),
 	}, decoder.Err
 }
 
-func decodeLabels(raw common.MapStr, err error) (*Labels, error) {
+func decodeLabels(raw common.MapStr, path string, err error) (*Labels, error) {
 	if err != nil {
 		return nil, err
 	}
-	decoder := utility.ManualDecoder{}
+	decoder := utility.ManualDecoder{Prefix: path}
 	if l := decoder.MapStr(raw, "tags"); decoder.Err == nil && l != nil {
 		labels := Labels(l)
 		return &labels, nil
@@ -305,11 +320,11 @@ func decodeLabels(raw common.MapStr, err error) (*Labels, error) {
 	return nil, decoder.Err
 }
 
-func decodeCustom(raw common.MapStr, err error) (*Custom, error) {
+func decodeCustom(raw common.MapStr, path string, err error) (*Custom, error) {
 	if err != nil {
 		return nil, err
 	}
-	decoder := utility.ManualDecoder{}
+	decoder := utility.ManualDecoder{Prefix: path}
 	if c := decoder.MapStr(raw, "custom"); decoder.Err == nil && c != nil {
 		custom := Custom(c)
 		return &custom, nil
@@ -172,3 +172,44 @@ func TestDecodeContext(t *testing.T) {
 		})
 	}
 }
+
+func TestDecodeContextAt(t *testing.T) {
+	for _, test := range []struct {
+		context interface{}
+		err     string
+	}{
+		{context: "a", err: "error.context: expected object, got string"},
+		{context: map[string]interface{}{"tags": "x"}, err: "error.context.tags: expected object, got string"},
+		{context: map[string]interface{}{"custom": []interface{}{}}, err: "error.context.custom: expected object, got array"},
+		{context: map[string]interface{}{"page": map[string]interface{}{"url": 1}}, err: "error.context.page.url: expected string, got number"},
+		{
+			context: map[string]interface{}{"request": map[string]interface{}{"method": "GET", "url": map[string]interface{}{"hostname": 1}}},
+			err:     "error.context.request.url.hostname: expected string, got number",
+		},
+		{
+			context: map[string]interface{}{"request": map[string]interface{}{"url": map[string]interface{}{}}},
+			err:     "error.context.request.method: expected string, got null",
+		},
+		{
+			context: map[string]interface{}{"request": map[string]interface{}{"method": "GET", "headers": map[string]interface{}{"a": 1}}},
+			err:     "error.context.request.headers.a: expected array of strings, got number",
+		},
+		{
+			context: map[string]interface{}{"response": map[string]interface{}{"status_code": "200"}},
+			err:     "error.context.response.status_code: expected integer, got string",
+		},
+		{context: map[string]interface{}{"user": "x"}, err: "error.context.user: expected object, got string"},
+		{context: map[string]interface{}{"user": map[string]interface{}{"email": true}}, err: "error.context.user.email: expected string, got boolean"},
+		{
+			context: map[string]interface{}{"service": map[string]interface{}{"agent": map[string]interface{}{"name": 1}}},
+			err:     "error.context.service.agent.name: expected string, got number",
+		},
+	} {
+		_, err := DecodeContextAt(map[string]interface{}{"context": test.context}, "error", Config{}, nil)
+		assert.EqualError(t, err, test.err)
+	}
+
+	// without a path decoding errors do not name the field
+	_, err := DecodeContext(map[string]interface{}{"context": map[string]interface{}{"tags": "x"}}, Config{}, nil)
+	assert.EqualError(t, err, "Error fetching field")
+}
@@ -0,0 +1,127 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package error
+
+import (
+	"math"
+	"math/bits"
+	"sync"
+	"time"
+)
+
+// cardinalityPrecision is the number of hash bits selecting a register
+// of the grouping key cardinality estimator; 2^10 registers give a
+// standard error of about 3%
+const cardinalityPrecision = 10
+
+// cardinalityEstimator estimates the number of distinct grouping keys per time
+// window with a HyperLogLog sketch, using constant memory regardless of the
+// number of keys.
+type cardinalityEstimator struct {
+	mu        sync.Mutex
+	start     time.Time
+	window    time.Duration
+	published int64
+	registers [1 << cardinalityPrecision]uint8
+}
+
+// add counts a grouping key seen at the given time, completing the current
+// window first if it has ended.
+func (c *cardinalityEstimator) add(key string, now time.Time, window time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.window = window
+	if c.start.IsZero() {
+		c.start = now
+	} else {
+		c.roll(now)
+	}
+	x := hashString(key)
+	idx := x >> (64 - cardinalityPrecision)
+	// the position of the leftmost 1 bit of the remaining bits
+	rank := uint8(bits.LeadingZeros64(x<<cardinalityPrecision|1<<(cardinalityPrecision-1))) + 1
+	if rank > c.registers[idx] {
+		c.registers[idx] = rank
+	}
+}
+
+// cardinality returns the estimate of the last window completed at the given
+// time, which is zero if no keys were seen during that window.
+func (c *cardinalityEstimator) cardinality(now time.Time) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.start.IsZero() {
+		c.roll(now)
+	}
+	return c.published
+}
+
+// roll completes the current window if it has ended by the given time, keeping
+// its estimate and starting the window now falls into. Windows passed without
+// any keys are estimated as zero.
+func (c *cardinalityEstimator) roll(now time.Time) {
+	if c.window <= 0 {
+		return
+	}
+	elapsed := now.Sub(c.start) / c.window
+	if elapsed < 1 {
+		return
+	}
+	if elapsed == 1 {
+		c.published = int64(math.Round(c.estimate()))
+	} else {
+		c.published = 0
+	}
+	c.registers = [1 << cardinalityPrecision]uint8{}
+	c.start = c.start.Add(elapsed * c.window)
+}
+
+// estimate returns the estimated number of distinct keys of the current
+// window, corrected for small cardinalities.
+func (c *cardinalityEstimator) estimate() float64 {
+	m := float64(len(c.registers))
+	var sum float64
+	var zeros int
+	for _, r := range c.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := 0.7213 / (1 + 1.079/m) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return estimate
+}
+
+// hashString returns the 64 bit FNV-1a hash of s, with the bits mixed by the
+// murmur3 finalizer as FNV-1a spreads short similar strings poorly.
+func hashString(s string) uint64 {
+	x := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		x ^= uint64(s[i])
+		x *= 1099511628211
+	}
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package error
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	m "github.com/elastic/apm-server/model"
+	"github.com/elastic/apm-server/transform"
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+func TestGroupingKeyCardinality(t *testing.T) {
+	now := time.Now()
+	for _, distinct := range []int{1, 10, 100, 1000, 10000, 100000} {
+		c := &cardinalityEstimator{}
+		for i := 0; i < distinct; i++ {
+			key := hex.EncodeToString(md5With(strconv.Itoa(i)))
+			// duplicates do not count
+			c.add(key, now, time.Minute)
+			c.add(key, now, time.Minute)
+		}
+		assert.InEpsilon(t, distinct, c.estimate(), 0.1, "distinct keys: %d", distinct)
+	}
+
+	// the estimate of a window is published once the window is complete
+	c := &cardinalityEstimator{}
+	assert.Equal(t, int64(0), c.cardinality(now))
+	for i := 0; i < 1000; i++ {
+		c.add(fmt.Sprintf("key-%d", i), now, time.Minute)
+	}
+	c.add("key-0", now.Add(time.Second), time.Minute)
+	estimate := c.estimate()
+	assert.Equal(t, int64(0), c.cardinality(now.Add(time.Second)))
+	c.add("key-0", now.Add(time.Minute), time.Minute)
+	assert.Equal(t, int64(math.Round(estimate)), c.cardinality(now.Add(time.Minute)))
+	assert.InEpsilon(t, 1000, c.cardinality(now.Add(time.Minute)), 0.1)
+	assert.InEpsilon(t, 1, c.estimate(), 0.01)
+
+	// the estimate is updated on read when no keys are added anymore
+	assert.Equal(t, int64(1), c.cardinality(now.Add(2*time.Minute)))
+	assert.Equal(t, int64(0), c.cardinality(now.Add(3*time.Minute)))
+
+	// windows passed without keys are estimated as zero
+	c = &cardinalityEstimator{}
+	c.add("key-0", now, time.Minute)
+	c.add("key-1", now.Add(2*time.Minute), time.Minute)
+	assert.Equal(t, int64(0), c.cardinality(now.Add(2*time.Minute)))
+
+	// transformed errors are counted if configured
+	defer func(c *cardinalityEstimator) { groupingKeys = c }(groupingKeys)
+	groupingKeys = &cardinalityEstimator{}
+	cfg := m.Config{GroupingKeyCardinalityWindow: time.Hour}
+	for i := 0; i < 3; i++ {
+		(&Event{Exception: baseException(), config: cfg}).Transform(&transform.Context{})
+	}
+	// the window is not complete yet
+	snapshot := monitoring.CollectFlatSnapshot(Metrics, monitoring.Full, false)
+	assert.Equal(t, int64(0), snapshot.Ints["grouping_key_cardinality"])
+	assert.Equal(t, int64(1), groupingKeys.cardinality(time.Now().Add(time.Hour)))
+}
@@ -0,0 +1,116 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package error
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+const defaultMaxDecompressedSize = 1 << 20
+
+// decompressValues replaces the compressed values of the custom context and
+// exception attributes by their decompressed value. All values of an event
+// share a budget of MaxDecompressedSize bytes.
+func (e *Event) decompressValues(stats *decodeStats) {
+	budget := e.config.MaxDecompressedSize
+	if budget <= 0 {
+		budget = defaultMaxDecompressedSize
+	}
+	if e.Custom != nil {
+		var custom interface{}
+		custom, budget = decompressValue(map[string]interface{}(*e.Custom), e.config.CompressedValueKey, budget, stats)
+		if custom, ok := custom.(map[string]interface{}); ok {
+			*e.Custom = custom
+		}
+	}
+	for _, ex := range e.exceptionChain() {
+		if ex.Attributes == nil {
+			continue
+		}
+		var attributes interface{}
+		attributes, budget = decompressValue(map[string]interface{}(ex.Attributes), e.config.CompressedValueKey, budget, stats)
+		if attributes, ok := attributes.(map[string]interface{}); ok {
+			ex.Attributes = attributes
+		}
+	}
+}
+
+// decompressValue returns v with its compressed values decompressed, along
+// with the remaining budget of decompressed bytes. Values exceeding the budget
+// are kept compressed. Values nested in decompressed values are not
+// decompressed again.
+func decompressValue(v interface{}, key string, budget int, stats *decodeStats) (interface{}, int) {
+	switch v := v.(type) {
+	case common.MapStr:
+		return decompressValue(map[string]interface{}(v), key, budget, stats)
+	case map[string]interface{}:
+		if encoded, ok := v[key].(string); ok && len(v) == 1 {
+			decoded, size, err := decompress(encoded, budget)
+			if err != nil {
+				stats.compressedValuesInvalid++
+				return v, budget
+			}
+			return decoded, budget - size
+		}
+		for k, value := range v {
+			v[k], budget = decompressValue(value, key, budget, stats)
+		}
+	case []interface{}:
+		for idx, value := range v {
+			v[idx], budget = decompressValue(value, key, budget, stats)
+		}
+	}
+	return v, budget
+}
+
+// decompress decodes base64 encoded, gzip compressed JSON, reading at most
+// limit decompressed bytes. It returns the decoded value along with the
+// number of decompressed bytes.
+func decompress(encoded string, limit int) (interface{}, int, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, 0, err
+	}
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer r.Close()
+	decompressed, err := ioutil.ReadAll(io.LimitReader(r, int64(limit)+1))
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(decompressed) > limit {
+		return nil, 0, fmt.Errorf("decompressed value exceeds %d bytes", limit)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(decompressed))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, 0, err
+	}
+	return v, len(decompressed), nil
+}
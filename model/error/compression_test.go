@@ -0,0 +1,143 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package error
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	m "github.com/elastic/apm-server/model"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestDecodeCompressedValues(t *testing.T) {
+	compress := func(s string) string {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		io.WriteString(w, s)
+		w.Close()
+		return base64.StdEncoding.EncodeToString(buf.Bytes())
+	}
+	input := func(custom, attributes map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"context":   map[string]interface{}{"custom": custom},
+			"exception": map[string]interface{}{"message": "m", "attributes": attributes},
+		}
+	}
+	order := compress(`{"id":"o-42","items":[1,2]}`)
+	bomb := compress(`"` + strings.Repeat("a", 1<<20) + `"`)
+	cfg := m.Config{CompressedValueKey: "$gzip"}
+
+	for name, test := range map[string]struct {
+		custom, attributes map[string]interface{}
+		cfg                m.Config
+		expectedCustom     *m.Custom
+		expectedAttributes common.MapStr
+		invalid            int64
+	}{
+		"compressed": {
+			custom:             map[string]interface{}{"order": map[string]interface{}{"$gzip": order}, "plain": "value"},
+			attributes:         map[string]interface{}{"$gzip": compress(`{"thread":"main"}`)},
+			cfg:                cfg,
+			expectedCustom:     &m.Custom{"order": map[string]interface{}{"id": "o-42", "items": []interface{}{json.Number("1"), json.Number("2")}}, "plain": "value"},
+			expectedAttributes: common.MapStr{"thread": "main"},
+		},
+		"nested": {
+			custom:         map[string]interface{}{"orders": []interface{}{map[string]interface{}{"$gzip": compress(`"o-1"`)}}},
+			cfg:            cfg,
+			expectedCustom: &m.Custom{"orders": []interface{}{"o-1"}},
+		},
+		"plain": {
+			custom:             map[string]interface{}{"order": map[string]interface{}{"$gzip": order, "other": "key"}},
+			attributes:         map[string]interface{}{"k": "v"},
+			cfg:                cfg,
+			expectedCustom:     &m.Custom{"order": map[string]interface{}{"$gzip": order, "other": "key"}},
+			expectedAttributes: common.MapStr{"k": "v"},
+		},
+		"disabled": {
+			custom:         map[string]interface{}{"order": map[string]interface{}{"$gzip": order}},
+			expectedCustom: &m.Custom{"order": map[string]interface{}{"$gzip": order}},
+		},
+		"invalid": {
+			custom:         map[string]interface{}{"a": map[string]interface{}{"$gzip": "not base64"}, "b": map[string]interface{}{"$gzip": base64.StdEncoding.EncodeToString([]byte("not gzip"))}},
+			cfg:            cfg,
+			expectedCustom: &m.Custom{"a": map[string]interface{}{"$gzip": "not base64"}, "b": map[string]interface{}{"$gzip": base64.StdEncoding.EncodeToString([]byte("not gzip"))}},
+			invalid:        2,
+		},
+		"bomb": {
+			custom:         map[string]interface{}{"order": map[string]interface{}{"$gzip": bomb}},
+			cfg:            m.Config{CompressedValueKey: "$gzip", MaxDecompressedSize: 1024},
+			expectedCustom: &m.Custom{"order": map[string]interface{}{"$gzip": bomb}},
+			invalid:        1,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			before := compressedValuesInvalid.Get()
+			transformable, err := DecodeEvent(input(test.custom, test.attributes), test.cfg, nil)
+			require.NoError(t, err)
+			event := transformable.(*Event)
+			assert.Equal(t, test.expectedCustom, event.Custom)
+			assert.Equal(t, test.expectedAttributes, event.Exception.Attributes)
+			assert.Equal(t, before+test.invalid, compressedValuesInvalid.Get())
+		})
+	}
+}
+
+func TestDecodeCompressedValuesBudget(t *testing.T) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	io.WriteString(w, `"`+strings.Repeat("a", 98)+`"`)
+	w.Close()
+	small := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	// 60 values of 100 bytes each, spread over custom context and attributes,
+	// share a budget of 1024 bytes
+	custom, attributes := map[string]interface{}{}, map[string]interface{}{}
+	for i := 0; i < 30; i++ {
+		custom[strconv.Itoa(i)] = map[string]interface{}{"$gzip": small}
+		attributes[strconv.Itoa(i)] = map[string]interface{}{"$gzip": small}
+	}
+	input := map[string]interface{}{
+		"context":   map[string]interface{}{"custom": custom},
+		"exception": map[string]interface{}{"message": "m", "attributes": attributes},
+	}
+	before := compressedValuesInvalid.Get()
+	transformable, err := DecodeEvent(input, m.Config{CompressedValueKey: "$gzip", MaxDecompressedSize: 1024}, nil)
+	require.NoError(t, err)
+	event := transformable.(*Event)
+
+	var decompressed int
+	for _, values := range []map[string]interface{}{*event.Custom, event.Exception.Attributes} {
+		for _, v := range values {
+			if _, ok := v.(string); ok {
+				decompressed++
+			}
+		}
+	}
+	assert.Equal(t, 10, decompressed)
+	assert.Equal(t, before+50, compressedValuesInvalid.Get())
+}
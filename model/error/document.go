@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package error
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// ErrorDocument is a typed view of the document an error is transformed into,
+// for consumers and tests asserting on documents without looking up fields
+// by name. Values missing from the document are left empty.
+type ErrorDocument struct {
+	Timestamp time.Time
+
+	Id                string
+	TraceId           string
+	TransactionId     string
+	ParentId          string
+	Culprit           string
+	GroupingKey       string
+	GroupingKeySource string
+	// Type is "exception", "log" or "both", see error.type
+	Type    string
+	Handled *bool
+
+	// ExceptionType and ExceptionMessage are the ones of the outermost
+	// exception of the chain
+	ExceptionType    string
+	ExceptionMessage string
+	LogMessage       string
+
+	// Fields holds the complete document
+	Fields common.MapStr
+}
+
+// NewErrorDocument returns the typed view of an event returned by
+// Event.Transform. It fails for events not holding an error document.
+func NewErrorDocument(event beat.Event) (*ErrorDocument, error) {
+	if _, ok := event.Fields["error"].(common.MapStr); !ok {
+		return nil, errors.New("error: not an error document")
+	}
+	doc := ErrorDocument{
+		Timestamp:         event.Timestamp,
+		Id:                stringValue(event.Fields, "error.id"),
+		TraceId:           stringValue(event.Fields, "trace.id"),
+		TransactionId:     stringValue(event.Fields, "transaction.id"),
+		ParentId:          stringValue(event.Fields, "parent.id"),
+		Culprit:           stringValue(event.Fields, "error.culprit"),
+		GroupingKey:       stringValue(event.Fields, "error.grouping_key"),
+		GroupingKeySource: stringValue(event.Fields, "error.grouping_key_source"),
+		Type:              stringValue(event.Fields, "error.type"),
+		LogMessage:        stringValue(event.Fields, "error.log.message"),
+		Fields:            event.Fields,
+	}
+	if handled, err := event.Fields.GetValue("error.handled"); err == nil {
+		if handled, ok := handled.(bool); ok {
+			doc.Handled = &handled
+		}
+	}
+	if exceptions, err := event.Fields.GetValue("error.exception"); err == nil {
+		if exceptions, ok := exceptions.([]common.MapStr); ok && len(exceptions) > 0 {
+			doc.ExceptionType = stringValue(exceptions[0], "type")
+			doc.ExceptionMessage = stringValue(exceptions[0], "message")
+		}
+	}
+	return &doc, nil
+}
+
+// stringValue returns the string found at the dotted key, or an empty string.
+func stringValue(fields common.MapStr, key string) string {
+	value, _ := fields.GetValue(key)
+	s, _ := value.(string)
+	return s
+}
@@ -0,0 +1,84 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package error
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/apm-server/transform"
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestErrorDocument(t *testing.T) {
+	id, traceId, txId, parentId, culprit := "0123456789abcdef", "0123456789abcdef0123456789abcdef", "fedcba9876543210", "abcdef0123456789", "handler"
+	exType, exMsg := "DbError", "connection refused"
+	handled := false
+	timestamp := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	e := Event{
+		Id:            &id,
+		TraceId:       &traceId,
+		TransactionId: &txId,
+		ParentId:      &parentId,
+		Culprit:       &culprit,
+		Timestamp:     timestamp,
+		Exception: &Exception{Type: &exType, Message: &exMsg, Handled: &handled, Cause: []Exception{
+			*baseException().withType("RootCause"),
+		}},
+		Log: baseLog(),
+	}
+	events := e.Transform(&transform.Context{})
+	require.Len(t, events, 1)
+	doc, err := NewErrorDocument(events[0])
+	require.NoError(t, err)
+	assert.Equal(t, &ErrorDocument{
+		Timestamp:         timestamp,
+		Id:                id,
+		TraceId:           traceId,
+		TransactionId:     txId,
+		ParentId:          parentId,
+		Culprit:           culprit,
+		GroupingKey:       e.calcGroupingKey(),
+		GroupingKeySource: "computed",
+		Type:              "both",
+		Handled:           &handled,
+		ExceptionType:     exType,
+		ExceptionMessage:  exMsg,
+		LogMessage:        "error log message",
+		Fields:            events[0].Fields,
+	}, doc)
+
+	// missing values are left empty
+	providedKey := "abc123"
+	e = Event{GroupingKey: &providedKey, Log: baseLog()}
+	doc, err = NewErrorDocument(e.Transform(&transform.Context{})[0])
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", doc.GroupingKey)
+	assert.Equal(t, "provided", doc.GroupingKeySource)
+	assert.Equal(t, "log", doc.Type)
+	assert.Equal(t, "", doc.ExceptionType)
+	assert.Equal(t, "", doc.TraceId)
+	assert.Nil(t, doc.Handled)
+
+	_, err = NewErrorDocument(beat.Event{Fields: common.MapStr{"transaction": common.MapStr{}}})
+	assert.EqualError(t, err, "error: not an error document")
+}
@@ -18,16 +18,17 @@
 package error
 
 import (
-	"crypto/md5"
-	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"hash"
-	"io"
+	"math"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/santhosh-tekuri/jsonschema"
 
 	m "github.com/elastic/apm-server/model"
@@ -46,12 +47,69 @@ var (
 	transformations   = monitoring.NewInt(Metrics, "transformations")
 	stacktraceCounter = monitoring.NewInt(Metrics, "stacktraces")
 	frameCounter      = monitoring.NewInt(Metrics, "frames")
+	withException     = monitoring.NewInt(Metrics, "with_exception")
+	withLog           = monitoring.NewInt(Metrics, "with_log")
+	withoutTrace      = monitoring.NewInt(Metrics, "without_trace")
+	timestampFallback = monitoring.NewInt(Metrics, "timestamp_fallbacks")
+	customDropped     = monitoring.NewInt(Metrics, "custom_dropped")
 	processorEntry    = common.MapStr{"name": processorName, "event": errorDocType}
+
+	attributesDropped    = monitoring.NewInt(Metrics, "attributes_dropped")
+	truncatedStacktraces = monitoring.NewInt(Metrics, "truncated_stacktraces")
+
+	contextDecodeFailures = monitoring.NewInt(Metrics, "context_decode_failures")
+	invalidIdsDropped     = monitoring.NewInt(Metrics, "invalid_ids_dropped")
+	labelsDropped         = monitoring.NewInt(Metrics, "labels_dropped")
+	customKeysDropped     = monitoring.NewInt(Metrics, "custom_keys_dropped")
+	emptyFramesDropped    = monitoring.NewInt(Metrics, "empty_frames_dropped")
+
+	// compressedValuesInvalid counts compressed values kept as sent for
+	// failing to decompress or exceeding the decompressed size limit
+	compressedValuesInvalid = monitoring.NewInt(Metrics, "compressed_values_invalid")
+
+	// exception chain depths, the total divided by with_exception gives the
+	// average depth
+	exceptionChainDepthTotal = monitoring.NewInt(Metrics, "exception_chain_depth_total")
+	exceptionChainDepthMax   = monitoring.NewInt(Metrics, "exception_chain_depth_max")
+	exceptionChainDepthMu    sync.Mutex
+
+	// sampledOut counts errors dropped by sampling
+	sampledOut = monitoring.NewInt(Metrics, "sampled_out")
+	sampler    = newGroupingKeySampler(maxSampledGroupingKeys)
+
+	// groupingKeyCardinality is the estimated number of distinct grouping
+	// keys of the last completed window, computed when the metrics are read
+	// so that it drops to zero once no errors are received anymore
+	groupingKeys           = &cardinalityEstimator{}
+	groupingKeyCardinality = monitoring.NewFunc(Metrics, "grouping_key_cardinality", func(_ monitoring.Mode, V monitoring.Visitor) {
+		V.OnInt(groupingKeys.cardinality(time.Now()))
+	})
+
+	// stacktraces and frames per error source, adding up to the totals above
+	exceptionStacktraceCounter = monitoring.NewInt(Metrics, "exception_stacktraces")
+	exceptionFrameCounter      = monitoring.NewInt(Metrics, "exception_frames")
+	logStacktraceCounter       = monitoring.NewInt(Metrics, "log_stacktraces")
+	logFrameCounter            = monitoring.NewInt(Metrics, "log_frames")
+
+	// decodeErrors counts all events failing to decode, which are further
+	// broken down by reason
+	decodeErrors             = monitoring.NewInt(Metrics, "decode_errors")
+	decodeErrorsMissingInput = monitoring.NewInt(Metrics, "decode_errors_missing_input")
+	decodeErrorsInvalidType  = monitoring.NewInt(Metrics, "decode_errors_invalid_type")
+	decodeErrorsStacktrace   = monitoring.NewInt(Metrics, "decode_errors_stacktrace")
+	decodeErrorsValidation   = monitoring.NewInt(Metrics, "decode_errors_validation")
+
+	libraryFrameToken, appFrameToken = "lib", "app"
+
+	// idNamespace scopes the deterministic ids synthesized for errors
+	idNamespace = uuid.NewV5(uuid.NamespaceURL, "https://www.elastic.co/apm/error")
 )
 
 const (
 	processorName = "error"
 	errorDocType  = "error"
+
+	defaultMaxExceptionCauseDepth = 32
 )
 
 var cachedModelSchema = validation.CreateSchema(schema.ModelSchema, processorName)
@@ -68,33 +126,55 @@ type Event struct {
 
 	Timestamp time.Time
 
-	Culprit *string
-	User    *metadata.User
-	Labels  *m.Labels
-	Page    *m.Page
-	Http    *m.Http
-	Url     *m.Url
-	Custom  *m.Custom
-	Service *metadata.Service
+	// Culprit is the culprit sent by the agent. The emitted culprit is
+	// derived from it on every transformation.
+	Culprit     *string
+	GroupingKey *string
+	User        *metadata.User
+	Labels      *m.Labels
+	Page        *m.Page
+	Http        *m.Http
+	Url         *m.Url
+	Custom      *m.Custom
+	Service     *metadata.Service
 
 	Exception *Exception
 	Log       *Log
+	// Logs holds the records of a `logs` array. Culprit and grouping key
+	// are derived from Log only.
+	Logs []Log
 
 	TransactionSampled *bool
 	TransactionType    *string
 
 	Experimental interface{}
 	data         common.MapStr
+	config       m.Config
+
+	// groupingComponents holds the values the grouping key was last
+	// computed from, if configured to be recorded
+	groupingComponents []string
 }
 
 type Exception struct {
 	Message    *string
 	Module     *string
 	Code       interface{}
-	Attributes interface{}
+	Attributes common.MapStr
 	Stacktrace m.Stacktrace
 	Type       *string
 	Handled    *bool
+	Cause      []Exception
+
+	// FramesOmitted is the number of stacktrace frames omitted by the agent
+	FramesOmitted *int
+
+	// CauseCount is the number of causes sent by the agent, including
+	// causes dropped for exceeding the maximum chain depth
+	CauseCount int
+
+	groupingFrames  *groupingFrames
+	framesTruncated int
 }
 
 type Log struct {
@@ -103,29 +183,209 @@ type Log struct {
 	ParamMessage *string
 	LoggerName   *string
 	Stacktrace   m.Stacktrace
+
+	// FramesOmitted is the number of stacktrace frames omitted by the agent
+	FramesOmitted *int
+
+	groupingFrames  *groupingFrames
+	framesTruncated int
+}
+
+// EventOption sets a property of an Event created with NewEvent.
+type EventOption func(*Event)
+
+// WithId sets the error id.
+func WithId(id string) EventOption {
+	return func(e *Event) { e.Id = &id }
+}
+
+// WithTimestamp sets the time the error occurred at.
+func WithTimestamp(ts time.Time) EventOption {
+	return func(e *Event) { e.Timestamp = ts }
+}
+
+// WithException sets the exception of the error.
+func WithException(ex Exception) EventOption {
+	return func(e *Event) { e.Exception = &ex }
+}
+
+// WithLog sets the log record of the error.
+func WithLog(log Log) EventOption {
+	return func(e *Event) { e.Log = &log }
+}
+
+// WithTransaction sets the transaction, and the trace and parent span it
+// belongs to.
+func WithTransaction(transactionId, traceId, parentId string) EventOption {
+	return func(e *Event) {
+		e.TransactionId, e.TraceId, e.ParentId = &transactionId, &traceId, &parentId
+	}
+}
+
+// WithConfig sets the configuration the error is validated and transformed
+// with.
+func WithConfig(cfg m.Config) EventOption {
+	return func(e *Event) { e.config = cfg }
+}
+
+// NewEvent creates an error event from the given options. The timestamp
+// defaults to the current time. The event must have an exception or a log
+// and is validated as decoded events are. Zero value events remain usable,
+// with the timestamp defaulting to the request time on transformation.
+func NewEvent(opts ...EventOption) (*Event, error) {
+	e := Event{Timestamp: time.Now()}
+	for _, opt := range opts {
+		opt(&e)
+	}
+	if e.Exception == nil && e.Log == nil {
+		return nil, errors.New("error: requires an exception or a log")
+	}
+	if e.Exception != nil && e.Exception.Message == nil && e.Exception.Type == nil {
+		return nil, errors.New("error.exception: requires a message or a type")
+	}
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// Merge fills the values missing from e with the ones of other, as when
+// coalescing an error resent by an agent. Present values are kept, labels
+// and custom context are merged key by key. The earlier of both timestamps
+// is kept. The configuration of e is not changed.
+//
+// The exception, log and logs taken from other are copied, but values nested
+// in them, such as stacktraces and attributes, as well as the context values
+// and strings taken from other, remain shared with other. other must not be
+// modified after merging it.
+func (e *Event) Merge(other *Event) {
+	if other == nil {
+		return
+	}
+	for _, field := range []struct{ dst, src **string }{
+		{&e.Id, &other.Id},
+		{&e.TransactionId, &other.TransactionId},
+		{&e.TraceId, &other.TraceId},
+		{&e.ParentId, &other.ParentId},
+		{&e.Culprit, &other.Culprit},
+		{&e.GroupingKey, &other.GroupingKey},
+		{&e.TransactionType, &other.TransactionType},
+	} {
+		if *field.dst == nil {
+			*field.dst = *field.src
+		}
+	}
+	if e.Timestamp.IsZero() || (!other.Timestamp.IsZero() && other.Timestamp.Before(e.Timestamp)) {
+		e.Timestamp = other.Timestamp
+	}
+	if e.User == nil {
+		e.User = other.User
+	}
+	if e.Page == nil {
+		e.Page = other.Page
+	}
+	if e.Http == nil {
+		e.Http = other.Http
+	}
+	if e.Url == nil {
+		e.Url = other.Url
+	}
+	if e.Service == nil {
+		e.Service = other.Service
+	}
+	if e.Exception == nil && other.Exception != nil {
+		ex := *other.Exception
+		e.Exception = &ex
+	}
+	if e.Log == nil && other.Log != nil {
+		log := *other.Log
+		e.Log = &log
+	}
+	if len(e.Logs) == 0 && len(other.Logs) > 0 {
+		e.Logs = append([]Log(nil), other.Logs...)
+	}
+	if e.TransactionSampled == nil {
+		e.TransactionSampled = other.TransactionSampled
+	}
+	if e.Experimental == nil {
+		e.Experimental = other.Experimental
+	}
+	if other.Labels != nil && len(*other.Labels) > 0 {
+		if e.Labels == nil {
+			e.Labels = &m.Labels{}
+		} else if *e.Labels == nil {
+			*e.Labels = m.Labels{}
+		}
+		mergeMissing(*e.Labels, *other.Labels)
+	}
+	if other.Custom != nil && len(*other.Custom) > 0 {
+		if e.Custom == nil {
+			e.Custom = &m.Custom{}
+		} else if *e.Custom == nil {
+			*e.Custom = m.Custom{}
+		}
+		mergeMissing(*e.Custom, *other.Custom)
+	}
+}
+
+// mergeMissing copies the keys of src missing from dst.
+func mergeMissing(dst, src map[string]interface{}) {
+	for key, value := range src {
+		if _, ok := dst[key]; !ok {
+			dst[key] = value
+		}
+	}
 }
 
 func DecodeEvent(input interface{}, cfg m.Config, err error) (transform.Transformable, error) {
 	if err != nil {
 		return nil, err
 	}
+	var e Event
+	if err := DecodeEventInto(&e, input, cfg); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// DecodeEventInto decodes an error into e, resetting all of its previous
+// state. It allows reusing events, e.g. from a pool. If decoding fails, e is
+// left in an undefined state.
+func DecodeEventInto(e *Event, input interface{}, cfg m.Config) error {
+	var stats decodeStats
+	err := decodeEvent(e, input, cfg, &stats)
+	stats.publish(err)
+	return err
+}
+
+// decodeEvent decodes an error into e, collecting the corrections made on
+// the way in stats instead of counting them right away.
+func decodeEvent(e *Event, input interface{}, cfg m.Config, stats *decodeStats) error {
 	if input == nil {
-		return nil, errors.New("Input missing for decoding Event")
+		return decodeFailure(ErrMissingInput, errors.New("Input missing for decoding Event"))
 	}
 
 	raw, ok := input.(map[string]interface{})
 	if !ok {
-		return nil, errors.New("invalid type for error event")
+		return decodeFailure(ErrInvalidType, errors.New("invalid type for error event"))
 	}
 
-	ctx, err := m.DecodeContext(raw, cfg, nil)
+	ctx, err := m.DecodeContextAt(raw, "error", cfg, nil)
 	if err != nil {
-		return nil, err
+		if !cfg.LenientContextDecoding {
+			return decodeFailure(ErrInvalidType, err)
+		}
+		stats.contextDecodeFailures++
+		if ctx == nil {
+			ctx = &m.Context{}
+		}
 	}
-	decoder := utility.ManualDecoder{}
-	e := Event{
+	decoder := utility.ManualDecoder{Prefix: "error"}
+	timestampDecoder := utility.ManualDecoder{Prefix: "error"}
+	*e = Event{
 		Id:                 decoder.StringPtr(raw, "id"),
 		Culprit:            decoder.StringPtr(raw, "culprit"),
+		GroupingKey:        decoder.StringPtr(raw, "grouping_key"),
 		Labels:             ctx.Labels,
 		Page:               ctx.Page,
 		Http:               ctx.Http,
@@ -134,69 +394,594 @@ func DecodeEvent(input interface{}, cfg m.Config, err error) (transform.Transfor
 		User:               ctx.User,
 		Service:            ctx.Service,
 		Experimental:       ctx.Experimental,
-		Timestamp:          decoder.TimeEpochMicro(raw, "timestamp"),
+		Timestamp:          decodeTimestamp(&timestampDecoder, raw, cfg),
 		TransactionId:      decoder.StringPtr(raw, "transaction_id"),
-		ParentId:           decoder.StringPtr(raw, "parent_id"),
+		ParentId:           decodeParentId(&decoder, raw),
 		TraceId:            decoder.StringPtr(raw, "trace_id"),
-		TransactionSampled: decoder.BoolPtr(raw, "sampled", "transaction"),
+		TransactionSampled: decodeBool(&decoder, cfg, raw, "sampled", "transaction"),
 		TransactionType:    decoder.StringPtr(raw, "type", "transaction"),
+		config:             cfg,
+	}
+	if timestampDecoder.Err != nil {
+		if cfg.TimestampFallback {
+			// a zero timestamp is replaced by the request time on transformation
+			stats.timestampFallbacks++
+		} else if decoder.Err == nil {
+			decoder.Err = timestampDecoder.Err
+		}
+	}
+
+	exception := decoder.MapStr(raw, "exception")
+	e.Exception, decoder.Err = decodeException(exception, "error.exception", 1, cfg, stats, decoder.Err)
+
+	logRaw, _ := raw["log"].(map[string]interface{})
+	e.Log, decoder.Err = decodeLog(logRaw, "error.log", cfg, stats, decoder.Err)
+	if cfg.DecodeLogsArray {
+		for idx, l := range decoder.InterfaceArr(raw, "logs") {
+			logPath := fmt.Sprintf("error.logs[%d]", idx)
+			logRaw, ok := l.(map[string]interface{})
+			if !ok {
+				decoder.Err = utility.NewFieldError(logPath, "object", l)
+				break
+			}
+			var log *Log
+			if log, decoder.Err = decodeLog(logRaw, logPath, cfg, stats, decoder.Err); log != nil {
+				e.Logs = append(e.Logs, *log)
+			}
+		}
+	}
+	if decoder.Err != nil {
+		if isStacktraceError(decoder.Err) {
+			return decodeFailure(ErrInvalidStacktrace, decoder.Err)
+		}
+		return decodeFailure(ErrInvalidType, decoder.Err)
+	}
+	if cfg.TraceIdValidation == "lenient" {
+		e.TraceId = dropInvalidId(e.TraceId, traceIdLength, stats)
+		e.ParentId = dropInvalidId(e.ParentId, parentIdLength, stats)
+	}
+	if cfg.CompressedValueKey != "" {
+		e.decompressValues(stats)
+	}
+	if cfg.ClampTimestamps && checkTimestamp(e.Timestamp, cfg) != nil {
+		// a zero timestamp is replaced by the request time on transformation
+		e.Timestamp = time.Time{}
+	}
+	if err := e.Validate(); err != nil {
+		return decodeFailure(ErrValidation, err)
+	}
+	if cfg.DefaultTransactionSampled && e.TransactionSampled == nil && e.TransactionId != nil && *e.TransactionId != "" {
+		sampled := true
+		e.TransactionSampled = &sampled
+	}
+
+	return nil
+}
+
+// maxEpochSeconds is the bound below which timestamps are taken for seconds
+// if configured. Given in microseconds, they would lie within the first two
+// days of 1970, in seconds within the year 5138.
+const maxEpochSeconds = 1e11
+
+// decodeTimestamp decodes the timestamp given in epoch microseconds or, as
+// sent by some third-party agents, as RFC3339 string or, if configured, in
+// epoch seconds. The decoder error is only set if no form can be decoded.
+func decodeTimestamp(decoder *utility.ManualDecoder, raw map[string]interface{}, cfg m.Config) time.Time {
+	if cfg.TimestampDetectSeconds {
+		if f, ok := epochNumber(raw["timestamp"]); ok && math.Abs(f) < maxEpochSeconds {
+			sec := math.Floor(f)
+			micros := math.Round((f - sec) * 1e6)
+			return time.Unix(int64(sec), int64(micros)*1000).UTC()
+		}
+	}
+	epochDecoder := utility.ManualDecoder{Prefix: decoder.Prefix}
+	timestamp := epochDecoder.TimeEpochMicro(raw, "timestamp")
+	if epochDecoder.Err == nil {
+		return timestamp
+	}
+	if s, ok := raw["timestamp"].(string); ok {
+		if timestamp, err := time.Parse(time.RFC3339Nano, s); err == nil {
+			return timestamp.UTC()
+		}
+	}
+	decoder.Err = epochDecoder.Err
+	return time.Time{}
+}
+
+// epochNumber returns the numeric value of a timestamp, as decoded from JSON
+// or set in a map built in code.
+func epochNumber(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// decodeParentId decodes the parent id given as parent.id, as sent by newer
+// agents, or as flat parent_id. The nested form takes precedence.
+func decodeParentId(decoder *utility.ManualDecoder, raw map[string]interface{}) *string {
+	if id := decoder.StringPtr(raw, "id", "parent"); id != nil {
+		return id
+	}
+	return decoder.StringPtr(raw, "parent_id")
+}
+
+// Errors returned by DecodeEvent can be matched against these reasons with
+// errors.Is.
+var (
+	ErrMissingInput      = errors.New("missing input")
+	ErrInvalidType       = errors.New("invalid type")
+	ErrInvalidStacktrace = errors.New("invalid stacktrace")
+	ErrValidation        = errors.New("validation failed")
+)
+
+var decodeFailureCounters = map[error]*monitoring.Int{
+	ErrMissingInput:      decodeErrorsMissingInput,
+	ErrInvalidType:       decodeErrorsInvalidType,
+	ErrInvalidStacktrace: decodeErrorsStacktrace,
+	ErrValidation:        decodeErrorsValidation,
+}
+
+// decodeError wraps the cause of a failed decoding, keeping its message,
+// and matches the reason of the failure.
+type decodeError struct {
+	reason error
+	err    error
+}
+
+func (e *decodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *decodeError) Unwrap() error {
+	return e.err
+}
+
+func (e *decodeError) Is(target error) bool {
+	return target == e.reason
+}
+
+// decodeFailure wraps the error of a failed decoding with its reason.
+func decodeFailure(reason error, err error) error {
+	return &decodeError{reason: reason, err: err}
+}
+
+// decodeStats collects the corrections made while decoding an error. They
+// are added to the monitoring counters once decoding is done, so that
+// decoding an error for previewing its grouping key leaves them untouched.
+type decodeStats struct {
+	contextDecodeFailures   int64
+	timestampFallbacks      int64
+	compressedValuesInvalid int64
+	invalidIdsDropped       int64
+	emptyFramesDropped      int64
+	truncatedStacktraces    int64
+	attributesDropped       int64
+}
+
+// publish adds the collected corrections to the monitoring counters and
+// counts a failed decoding by its reason.
+func (s *decodeStats) publish(err error) {
+	contextDecodeFailures.Add(s.contextDecodeFailures)
+	timestampFallback.Add(s.timestampFallbacks)
+	compressedValuesInvalid.Add(s.compressedValuesInvalid)
+	invalidIdsDropped.Add(s.invalidIdsDropped)
+	emptyFramesDropped.Add(s.emptyFramesDropped)
+	truncatedStacktraces.Add(s.truncatedStacktraces)
+	attributesDropped.Add(s.attributesDropped)
+
+	var decodeErr *decodeError
+	if errors.As(err, &decodeErr) {
+		decodeErrors.Inc()
+		decodeFailureCounters[decodeErr.reason].Inc()
 	}
+}
+
+// isStacktraceError tells whether a decoding error was caused by an invalid
+// exception or log stacktrace.
+func isStacktraceError(err error) bool {
+	var fieldErr *utility.FieldError
+	return errors.As(err, &fieldErr) && strings.Contains(fieldErr.Path, ".stacktrace")
+}
+
+// Validate checks the error for semantic problems beyond the types of its
+// fields, as configured when decoding it. DecodeEvent validates all errors it
+// decodes.
+func (e *Event) Validate() error {
+	if err := checkTimestamp(e.Timestamp, e.config); err != nil {
+		return err
+	}
+	if e.GroupingKey != nil && !isHex(*e.GroupingKey) {
+		return errors.New("error.grouping_key: expected non-empty hex string")
+	}
+	if e.config.RequireExceptionOrLog && e.Exception == nil && e.Log == nil && len(e.Logs) == 0 {
+		return errors.New("error: requires an exception or a log")
+	}
+	if e.config.RequireTraceId && e.TransactionId != nil && e.TraceId == nil {
+		return errors.New("error.trace_id: required when error.transaction_id is set")
+	}
+	if e.config.TraceIdValidation == "strict" {
+		if e.TraceId != nil && !isHexId(*e.TraceId, traceIdLength) {
+			return fmt.Errorf("error.trace_id: expected %d hex characters", traceIdLength)
+		}
+		if e.ParentId != nil && !isHexId(*e.ParentId, parentIdLength) {
+			return fmt.Errorf("error.parent_id: expected %d hex characters", parentIdLength)
+		}
+	}
+	return nil
+}
 
+const (
+	traceIdLength  = 32
+	parentIdLength = 16
+)
+
+// isHexId reports whether id is a hex string of the given length.
+func isHexId(id string, length int) bool {
+	return len(id) == length && isHex(id)
+}
+
+// dropInvalidId returns nil and counts the id as dropped if it is not a hex
+// string of the given length.
+func dropInvalidId(id *string, length int, stats *decodeStats) *string {
+	if id == nil || isHexId(*id, length) {
+		return id
+	}
+	stats.invalidIdsDropped++
+	return nil
+}
+
+// checkTimestamp verifies the timestamp lies within the configured skew from
+// now. A zero timestamp is replaced by the request time when transforming
+// the event and always passes.
+func checkTimestamp(ts time.Time, cfg m.Config) error {
+	if ts.IsZero() {
+		return nil
+	}
+	now := time.Now()
+	var err error
+	if cfg.MaxTimestampPastSkew > 0 && ts.Before(now.Add(-cfg.MaxTimestampPastSkew)) {
+		err = fmt.Errorf("error.timestamp: %s is more than %s in the past", ts.Format(time.RFC3339Nano), cfg.MaxTimestampPastSkew)
+	} else if cfg.MaxTimestampFutureSkew > 0 && ts.After(now.Add(cfg.MaxTimestampFutureSkew)) {
+		err = fmt.Errorf("error.timestamp: %s is more than %s in the future", ts.Format(time.RFC3339Nano), cfg.MaxTimestampFutureSkew)
+	}
+	return err
+}
+
+// decodeLog decodes the log record found at the given path of the payload.
+// A record without a message is ignored.
+func decodeLog(raw map[string]interface{}, path string, cfg m.Config, stats *decodeStats, err error) (*Log, error) {
+	if raw == nil || err != nil {
+		return nil, err
+	}
+	decoder := utility.ManualDecoder{Prefix: path}
+	logMsg := decoder.StringPtr(raw, "message")
+	if logMsg == nil {
+		return nil, decoder.Err
+	}
+	log := Log{
+		Message:      *logMsg,
+		ParamMessage: decoder.StringPtr(raw, "param_message"),
+		Level:        decoder.StringPtr(raw, "level"),
+		LoggerName:   decoder.StringPtr(raw, "logger_name"),
+		Stacktrace:   m.Stacktrace{},
+	}
 	var stacktr *m.Stacktrace
-	ex := decoder.MapStr(raw, "exception")
-	exMsg := decoder.StringPtr(ex, "message")
-	exType := decoder.StringPtr(ex, "type")
-	if exMsg != nil || exType != nil {
-		e.Exception = &Exception{
-			Message:    exMsg,
-			Type:       exType,
-			Code:       decoder.Interface(ex, "code"),
-			Module:     decoder.StringPtr(ex, "module"),
-			Attributes: decoder.Interface(ex, "attributes"),
-			Handled:    decoder.BoolPtr(ex, "handled"),
-			Stacktrace: m.Stacktrace{},
-		}
-		stacktr, decoder.Err = m.DecodeStacktrace(ex["stacktrace"], decoder.Err)
-		if stacktr != nil {
-			e.Exception.Stacktrace = *stacktr
-		}
-	}
-
-	log := decoder.MapStr(raw, "log")
-	logMsg := decoder.StringPtr(log, "message")
-	if logMsg != nil {
-		e.Log = &Log{
-			Message:      *logMsg,
-			ParamMessage: decoder.StringPtr(log, "param_message"),
-			Level:        decoder.StringPtr(log, "level"),
-			LoggerName:   decoder.StringPtr(log, "logger_name"),
-			Stacktrace:   m.Stacktrace{},
-		}
-		stacktr, decoder.Err = m.DecodeStacktrace(log["stacktrace"], decoder.Err)
-		if stacktr != nil {
-			e.Log.Stacktrace = *stacktr
+	log.groupingFrames = newGroupingFrames(cfg)
+	stacktr, log.FramesOmitted, decoder.Err = decodeStacktrace(decoder.Interface(raw, "stacktrace"), path+".stacktrace", log.groupingFrames.visitor(), decoder.Err)
+	if stacktr != nil {
+		log.Stacktrace, log.framesTruncated = truncateStacktrace(collapseStacktrace(dropEmptyFrames(*stacktr, cfg, stats), cfg), cfg, stats)
+	}
+	if decoder.Err != nil {
+		return nil, decoder.Err
+	}
+	return &log, nil
+}
+
+// decodeBool decodes the boolean found at the given key, also accepting the
+// strings "true", "false", "1" and "0" if configured so.
+func decodeBool(decoder *utility.ManualDecoder, cfg m.Config, raw map[string]interface{}, key string, keys ...string) *bool {
+	if cfg.LenientBoolDecoding {
+		if s, ok := decoder.Interface(raw, key, keys...).(string); ok {
+			var b bool
+			switch s {
+			case "true", "1":
+				b = true
+				return &b
+			case "false", "0":
+				return &b
+			}
 		}
 	}
+	return decoder.BoolPtr(raw, key, keys...)
+}
+
+// decodeExceptionType decodes the exception type, given as string or, by
+// agents of languages with multiple exception classes, as array starting
+// with the most specific class, which is used as type then.
+func decodeExceptionType(decoder *utility.ManualDecoder, raw map[string]interface{}) *string {
+	types, ok := raw["type"].([]interface{})
+	if !ok {
+		return decoder.StringPtr(raw, "type")
+	}
+	if len(types) == 0 {
+		return nil
+	}
+	exType, ok := types[0].(string)
+	if !ok {
+		decoder.Err = utility.NewFieldError(decoder.Prefix+".type[0]", "string", types[0])
+		return nil
+	}
+	return &exType
+}
+
+// decodeStacktrace decodes the stacktrace found at the given path, sent as
+// array of frames or as object holding the frames and the number of frames
+// omitted by the agent. Decoded frames are passed to visit if not nil.
+func decodeStacktrace(input interface{}, path string, visit func(*m.StacktraceFrame), err error) (*m.Stacktrace, *int, error) {
+	if err != nil {
+		return nil, nil, err
+	}
+	var framesOmitted *int
+	if raw, ok := input.(map[string]interface{}); ok {
+		decoder := utility.ManualDecoder{Prefix: path}
+		if framesOmitted = decoder.IntPtr(raw, "frames_omitted"); decoder.Err != nil {
+			return nil, nil, decoder.Err
+		}
+		input, path = raw["frames"], path+".frames"
+	}
+	st, err := m.DecodeStacktraceFunc(input, path, visit, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return st, framesOmitted, nil
+}
+
+// dropEmptyFrames drops the frames with neither a filename nor a function, if
+// configured to.
+func dropEmptyFrames(st m.Stacktrace, cfg m.Config, stats *decodeStats) m.Stacktrace {
+	if !cfg.DropEmptyFrames {
+		return st
+	}
+	kept := st[:0]
+	for _, fr := range st {
+		if isEmptyFrame(fr) {
+			stats.emptyFramesDropped++
+			continue
+		}
+		kept = append(kept, fr)
+	}
+	return kept
+}
+
+// isEmptyFrame reports whether a frame has neither a filename nor a function.
+func isEmptyFrame(fr *m.StacktraceFrame) bool {
+	return fr == nil || (fr.Filename == "" && (fr.Function == nil || *fr.Function == ""))
+}
+
+// collapseStacktrace drops the frames identical to their preceding frame, if
+// configured to collapse repeated frames.
+func collapseStacktrace(st m.Stacktrace, cfg m.Config) m.Stacktrace {
+	if !cfg.CollapseRepeatedFrames || len(st) < 2 {
+		return st
+	}
+	collapsed := st[:1]
+	for _, fr := range st[1:] {
+		if !sameFrame(collapsed[len(collapsed)-1], fr) {
+			collapsed = append(collapsed, fr)
+		}
+	}
+	return collapsed
+}
+
+// sameFrame reports whether two frames point to the same code location.
+func sameFrame(a, b *m.StacktraceFrame) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Filename == b.Filename && a.Lineno == b.Lineno &&
+		equalStringPtr(a.AbsPath, b.AbsPath) && equalStringPtr(a.Module, b.Module) &&
+		equalStringPtr(a.Function, b.Function) && equalIntPtr(a.Colno, b.Colno)
+}
+
+func equalStringPtr(a, b *string) bool {
+	return a == b || (a != nil && b != nil && *a == *b)
+}
+
+func equalIntPtr(a, b *int) bool {
+	return a == b || (a != nil && b != nil && *a == *b)
+}
+
+// truncateStacktrace keeps the top frames of a stacktrace exceeding the
+// configured maximum number of frames, returning the number of frames
+// dropped.
+func truncateStacktrace(st m.Stacktrace, cfg m.Config, stats *decodeStats) (m.Stacktrace, int) {
+	if cfg.MaxStacktraceFrames <= 0 || len(st) <= cfg.MaxStacktraceFrames {
+		return st, 0
+	}
+	stats.truncatedStacktraces++
+	return st[:cfg.MaxStacktraceFrames], len(st) - cfg.MaxStacktraceFrames
+}
+
+// decodeException decodes the exception found at the given path of the
+// payload and, recursively, its chained causes. Causes nested deeper than
+// the configured maximum depth are dropped.
+func decodeException(raw map[string]interface{}, path string, depth int, cfg m.Config, stats *decodeStats, err error) (*Exception, error) {
+	if raw == nil || err != nil {
+		return nil, err
+	}
+	decoder := utility.ManualDecoder{Prefix: path}
+	exMsg := decoder.StringPtr(raw, "message")
+	exType := decodeExceptionType(&decoder, raw)
+	if exMsg == nil && exType == nil {
+		return nil, decoder.Err
+	}
+	ex := Exception{
+		Message:    exMsg,
+		Type:       exType,
+		Code:       decoder.Interface(raw, "code"),
+		Module:     decoder.StringPtr(raw, "module"),
+		Handled:    decodeBool(&decoder, cfg, raw, "handled"),
+		Stacktrace: m.Stacktrace{},
+	}
+	attrDecoder := utility.ManualDecoder{Prefix: path}
+	if attrs := attrDecoder.MapStr(raw, "attributes"); attrs != nil {
+		ex.Attributes = common.MapStr(attrs)
+	}
+	if attrDecoder.Err != nil {
+		if cfg.DropInvalidExceptionAttributes {
+			stats.attributesDropped++
+		} else if decoder.Err == nil {
+			decoder.Err = attrDecoder.Err
+		}
+	}
+	var stacktr *m.Stacktrace
+	ex.groupingFrames = newGroupingFrames(cfg)
+	stacktr, ex.FramesOmitted, decoder.Err = decodeStacktrace(raw["stacktrace"], path+".stacktrace", ex.groupingFrames.visitor(), decoder.Err)
+	if stacktr != nil {
+		ex.Stacktrace, ex.framesTruncated = truncateStacktrace(collapseStacktrace(dropEmptyFrames(*stacktr, cfg, stats), cfg), cfg, stats)
+	}
+
+	causes := decoder.InterfaceArr(raw, "cause")
 	if decoder.Err != nil {
 		return nil, decoder.Err
 	}
+	ex.CauseCount = len(causes)
+	if depth >= maxExceptionCauseDepth(cfg) {
+		return &ex, nil
+	}
+	for idx, c := range causes {
+		causePath := fmt.Sprintf("%s.cause[%d]", path, idx)
+		causeRaw, ok := c.(map[string]interface{})
+		if !ok {
+			return nil, utility.NewFieldError(causePath, "object", c)
+		}
+		cause, err := decodeException(causeRaw, causePath, depth+1, cfg, stats, nil)
+		if err != nil {
+			return nil, err
+		}
+		if cause != nil {
+			ex.Cause = append(ex.Cause, *cause)
+		}
+	}
+	return &ex, nil
+}
 
-	return &e, nil
+func maxExceptionCauseDepth(cfg m.Config) int {
+	if cfg.MaxExceptionCauseDepth > 0 {
+		return cfg.MaxExceptionCauseDepth
+	}
+	return defaultMaxExceptionCauseDepth
+}
+
+// flatten returns the exception followed by its chained causes, starting
+// with the outermost exception and ending with the root cause. At most
+// maxDepth levels of the chain are walked.
+func (e *Exception) flatten(maxDepth int) []*Exception {
+	var chain []*Exception
+	var walk func(ex *Exception, depth int)
+	walk = func(ex *Exception, depth int) {
+		chain = append(chain, ex)
+		if depth >= maxDepth {
+			return
+		}
+		for i := range ex.Cause {
+			walk(&ex.Cause[i], depth+1)
+		}
+	}
+	walk(e, 1)
+	return chain
 }
 
 func (e *Event) Transform(tctx *transform.Context) []beat.Event {
+	// the document is built before sampling, so errors are sampled by the
+	// grouping key they are emitted with, computed from the transformed frames
+	fields := e.Document(tctx)
+	if e.config.SampleRate > 1 && !e.sample(tctx, fields) {
+		sampledOut.Inc()
+		return nil
+	}
 	transformations.Inc()
-
 	if e.Exception != nil {
-		addStacktraceCounter(e.Exception.Stacktrace)
+		withException.Inc()
 	}
-	if e.Log != nil {
-		addStacktraceCounter(e.Log.Stacktrace)
+	if e.Log != nil || len(e.Logs) > 0 {
+		withLog.Inc()
+	}
+	if e.TraceId == nil {
+		withoutTrace.Inc()
 	}
 
+	chain := e.exceptionChain()
+	for _, ex := range chain {
+		addStacktraceCounter(ex.Stacktrace, exceptionStacktraceCounter, exceptionFrameCounter)
+		logTruncatedStacktrace(tctx, "exception", ex.framesTruncated)
+	}
+	if len(chain) > 0 {
+		recordExceptionChainDepth(int64(len(chain)))
+	}
+	for _, log := range e.logRecords() {
+		addStacktraceCounter(log.Stacktrace, logStacktraceCounter, logFrameCounter)
+		logTruncatedStacktrace(tctx, "log", log.framesTruncated)
+	}
+
+	if e.config.SplitExceptionChain {
+		if docs := splitExceptionChain(fields); docs != nil {
+			events := make([]beat.Event, len(docs))
+			for idx, doc := range docs {
+				events[idx] = beat.Event{Fields: doc, Timestamp: e.Timestamp}
+			}
+			return events
+		}
+	}
+	return []beat.Event{
+		{
+			Fields:    fields,
+			Timestamp: e.Timestamp,
+		},
+	}
+}
+
+// splitExceptionChain returns a copy of the document per exception of the
+// chain, each holding only its exception, tagged with the position in the
+// chain. It returns nil if the document holds no exception.
+func splitExceptionChain(fields common.MapStr) []common.MapStr {
+	errorFields, _ := fields["error"].(common.MapStr)
+	exceptions, _ := errorFields["exception"].([]common.MapStr)
+	if len(exceptions) == 0 {
+		return nil
+	}
+	docs := make([]common.MapStr, len(exceptions))
+	for idx, ex := range exceptions {
+		ex["chain_position"] = idx
+		if idx == len(exceptions)-1 {
+			// the last document can take over the original
+			docs[idx] = fields
+		} else {
+			docs[idx] = fields.Clone()
+		}
+		docs[idx]["error"].(common.MapStr)["exception"] = []common.MapStr{ex}
+	}
+	return docs
+}
+
+// Document returns the error document as built by Transform, without
+// wrapping it into a beat.Event. A nil tctx is treated as an empty context.
+func (e *Event) Document(tctx *transform.Context) common.MapStr {
+	if tctx == nil {
+		tctx = &transform.Context{}
+	}
+	if e.Timestamp.IsZero() {
+		e.Timestamp = tctx.RequestTime
+	}
+	e.Timestamp = e.truncateTime(e.Timestamp)
 	fields := common.MapStr{
 		"error":     e.fields(tctx),
-		"processor": processorEntry,
+		"processor": e.processorEntry(),
 	}
 
 	// first set the generic metadata (order is relevant)
@@ -209,6 +994,7 @@ func (e *Event) Transform(tctx *transform.Context) []beat.Event {
 	utility.DeepUpdate(fields, "agent", e.Service.AgentFields())
 	// merges with metadata labels, overrides conflicting keys
 	utility.DeepUpdate(fields, "labels", e.Labels.Fields())
+	e.limitLabels(fields)
 	utility.Set(fields, "http", e.Http.Fields())
 	utility.Set(fields, "url", e.Url.Fields())
 	utility.Set(fields, "experimental", e.Experimental)
@@ -226,17 +1012,153 @@ func (e *Event) Transform(tctx *transform.Context) []beat.Event {
 	utility.AddId(fields, "parent", e.ParentId)
 	utility.AddId(fields, "trace", e.TraceId)
 
-	if e.Timestamp.IsZero() {
-		e.Timestamp = tctx.RequestTime
-	}
 	utility.Set(fields, "timestamp", utility.TimeAsMicros(e.Timestamp))
+	if !e.config.OmitEventIngested && !tctx.RequestTime.IsZero() {
+		utility.Set(fields, "event", common.MapStr{"ingested": e.truncateTime(tctx.RequestTime)})
+	}
+	e.promoteLabels(tctx, fields)
+	e.addGroupingName(fields)
+	e.filterContextFields(fields)
+	return fields
+}
 
-	return []beat.Event{
-		{
-			Fields:    fields,
-			Timestamp: e.Timestamp,
-		},
+// truncateTime truncates t to the configured timestamp resolution. Without
+// a configured resolution t is kept as is, timestamp.us being given in
+// microseconds anyway.
+func (e *Event) truncateTime(t time.Time) time.Time {
+	switch e.config.TimestampResolution {
+	case "second":
+		return t.Truncate(time.Second)
+	case "millisecond":
+		return t.Truncate(time.Millisecond)
+	case "microsecond":
+		return t.Truncate(time.Microsecond)
+	default:
+		return t
+	}
+}
+
+// limitLabels drops the labels exceeding the configured maximum number of
+// labels, keeping the ones with the lowest sorted keys.
+func (e *Event) limitLabels(fields common.MapStr) {
+	labels, ok := fields["labels"].(common.MapStr)
+	if !ok || e.config.MaxLabels <= 0 || len(labels) <= e.config.MaxLabels {
+		return
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys[e.config.MaxLabels:] {
+		delete(labels, key)
 	}
+	labelsDropped.Add(int64(len(keys) - e.config.MaxLabels))
+}
+
+// promoteLabels copies the configured labels to their target fields. Labels
+// whose target field is already set are logged and skipped.
+func (e *Event) promoteLabels(tctx *transform.Context, fields common.MapStr) {
+	if len(e.config.PromoteLabels) == 0 {
+		return
+	}
+	labels, ok := fields["labels"].(common.MapStr)
+	if !ok {
+		return
+	}
+	names := make([]string, 0, len(e.config.PromoteLabels))
+	for name := range e.config.PromoteLabels {
+		names = append(names, name)
+	}
+	// sort names so the first label mapped to a field wins deterministically
+	sort.Strings(names)
+	for _, name := range names {
+		value, ok := labels[name]
+		if !ok {
+			continue
+		}
+		target := e.config.PromoteLabels[name]
+		if exists, _ := fields.HasKey(target); exists {
+			tctx.Log("transform").Warnf("label %s not promoted, field %s already set", name, target)
+			continue
+		}
+		if _, err := fields.Put(target, value); err != nil {
+			tctx.Log("transform").Warnf("label %s not promoted to %s: %s", name, target, err)
+		}
+	}
+}
+
+// addGroupingName sets error.grouping_name to the values of the configured
+// fields joined with ":". It is not set if none of the fields is present.
+func (e *Event) addGroupingName(fields common.MapStr) {
+	if len(e.config.GroupingNameComponents) == 0 {
+		return
+	}
+	values := make([]string, len(e.config.GroupingNameComponents))
+	var found bool
+	for idx, path := range e.config.GroupingNameComponents {
+		value, err := fields.GetValue(path)
+		if err != nil || value == nil {
+			continue
+		}
+		values[idx] = fmt.Sprint(value)
+		found = true
+	}
+	if found {
+		fields.Put("error.grouping_name", strings.Join(values, ":"))
+	}
+}
+
+// contextFields are the document paths of the optional context blocks of an
+// error.
+var contextFields = []string{"user", "client", "user_agent", "http", "url", "error.page", "error.custom"}
+
+// filterContextFields removes the context fields not included or explicitly
+// excluded by the configuration.
+func (e *Event) filterContextFields(fields common.MapStr) {
+	if len(e.config.ContextFieldsInclude) > 0 {
+		for _, path := range contextFields {
+			if !containsString(e.config.ContextFieldsInclude, path) {
+				fields.Delete(path)
+			}
+		}
+	}
+	for _, path := range e.config.ContextFieldsExclude {
+		fields.Delete(path)
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+// kind returns "exception", "log" or "both" depending on whether the error
+// holds an exception, log records or both, or nil if it holds neither.
+func (e *Event) kind() *string {
+	var kind string
+	switch hasLog := len(e.logRecords()) > 0; {
+	case e.Exception != nil && hasLog:
+		kind = "both"
+	case e.Exception != nil:
+		kind = "exception"
+	case hasLog:
+		kind = "log"
+	default:
+		return nil
+	}
+	return &kind
+}
+
+func (e *Event) processorEntry() common.MapStr {
+	if e.config.ErrorEventType == "" {
+		return processorEntry
+	}
+	return common.MapStr{"name": processorName, "event": e.config.ErrorEventType}
 }
 
 func (e *Event) fields(tctx *transform.Context) common.MapStr {
@@ -246,35 +1168,184 @@ func (e *Event) fields(tctx *transform.Context) common.MapStr {
 
 	e.addException(tctx)
 	e.addLog(tctx)
+	e.add("type", e.kind())
 
-	e.updateCulprit(tctx)
-	e.add("culprit", e.Culprit)
-	e.add("custom", e.Custom.Fields())
+	culprit, originalCulprit := e.culprit(tctx)
+	e.add("culprit", redact(culprit, e.config))
+	e.add("original_culprit", redact(originalCulprit, e.config))
+	e.add("custom", e.customFields(tctx))
 
-	e.addGroupingKey()
+	if !e.config.DisableGroupingKey {
+		e.addGroupingKey(tctx)
+	}
+	if e.Id == nil {
+		e.add("id", e.synthesizeId())
+	}
 
 	return e.data
 }
 
-func (e *Event) updateCulprit(tctx *transform.Context) {
+// synthesizeId returns an id for an error sent without one, generated as
+// configured by SynthesizeId. Deterministic ids are derived from the
+// emitted grouping key, so it needs to be added to the fields beforehand, or
+// from a computed one if grouping keys are disabled.
+func (e *Event) synthesizeId() *string {
+	var id uuid.UUID
+	switch e.config.SynthesizeId {
+	case "deterministic":
+		groupingKey, ok := e.data["grouping_key"].(string)
+		if !ok {
+			groupingKey = e.calcGroupingKey()
+		}
+		id = uuid.NewV5(idNamespace, groupingKey+"/"+strconv.FormatInt(e.Timestamp.UnixNano(), 10))
+	case "random":
+		var err error
+		if id, err = uuid.NewV4(); err != nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+// culprit returns the culprit to be emitted, along with the culprit sent by
+// the agent if it was replaced by one derived from sourcemapped frames.
+func (e *Event) culprit(tctx *transform.Context) (*string, *string) {
+	find := findSmappedNonLibraryFrame
 	if tctx.Config.SmapMapper == nil {
-		return
+		// without sourcemapping only derive a culprit if the agent did not send one
+		if e.Culprit != nil {
+			return e.Culprit, nil
+		}
+		find = findNonLibraryFrame
 	}
 	var fr *m.StacktraceFrame
 	if e.Log != nil {
-		fr = findSmappedNonLibraryFrame(e.Log.Stacktrace)
+		fr = find(e.Log.Stacktrace)
 	}
 	if fr == nil && e.Exception != nil {
-		fr = findSmappedNonLibraryFrame(e.Exception.Stacktrace)
+		fr = find(e.Exception.Stacktrace)
 	}
 	if fr == nil {
-		return
+		return e.Culprit, nil
+	}
+	culprit := formatCulprit(tctx, e.config, fr)
+	culprit = truncate(culprit, e.config.MaxCulpritLength)
+	if e.Culprit != nil && *e.Culprit != culprit {
+		return &culprit, e.Culprit
+	}
+	return &culprit, nil
+}
+
+// formatCulprit formats the culprit derived from the given frame, using the
+// configured template if any. If executing the template fails, the culprit
+// is formatted as "filename in function".
+func formatCulprit(tctx *transform.Context, cfg m.Config, fr *m.StacktraceFrame) string {
+	if cfg.CulpritTemplate != nil {
+		data := struct {
+			Filename string
+			Function string
+			Lineno   int
+		}{Filename: fr.Filename, Lineno: fr.Lineno}
+		if fr.Function != nil {
+			data.Function = *fr.Function
+		}
+		var b strings.Builder
+		err := cfg.CulpritTemplate.Execute(&b, data)
+		if err == nil {
+			return b.String()
+		}
+		tctx.Log("transform").Warnf("failed to format culprit: %s", err)
 	}
 	culprit := fmt.Sprintf("%v", fr.Filename)
 	if fr.Function != nil {
 		culprit += fmt.Sprintf(" in %v", *fr.Function)
 	}
-	e.Culprit = &culprit
+	return culprit
+}
+
+// redact applies the configured redaction rules to an optional string.
+func redact(s *string, cfg m.Config) *string {
+	if s == nil || len(cfg.RedactionRules) == 0 {
+		return s
+	}
+	redacted := cfg.Redact(*s)
+	return &redacted
+}
+
+const ellipsis = "..."
+
+// truncate shortens s to at most max characters, ending in an ellipsis if
+// anything was cut off. A max of zero or less disables truncation.
+func truncate(s string, max int) string {
+	if max <= 0 {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	if max <= len(ellipsis) {
+		return string(runes[:max])
+	}
+	return string(runes[:max-len(ellipsis)]) + ellipsis
+}
+
+// emittedStacktrace transforms the stacktrace, returning the frames to be
+// emitted in the configured stacktrace mode. All frames are transformed, as
+// sourcemapping a frame depends on the frames below.
+func emittedStacktrace(tctx *transform.Context, cfg m.Config, st m.Stacktrace) []common.MapStr {
+	frames := st.Transform(tctx)
+	if cfg.StacktraceMode != "top_frame" || len(frames) == 0 {
+		return frames
+	}
+	for idx, fr := range st {
+		if !fr.IsLibraryFrame() {
+			return frames[idx : idx+1]
+		}
+	}
+	return frames[:1]
+}
+
+// sourcemapsApplied reports whether sourcemaps are applied to the frames of
+// st when transforming it.
+func sourcemapsApplied(tctx *transform.Context, st m.Stacktrace) bool {
+	return len(st) > 0 && tctx.Config.SmapMapper != nil && tctx.Metadata.Service != nil
+}
+
+// sourcemapApplied reports whether a sourcemap was applied to any frame of
+// the transformed stacktrace. It returns nil for empty stacktraces and if
+// sourcemaps are not applied.
+func sourcemapApplied(tctx *transform.Context, st m.Stacktrace) *bool {
+	if !sourcemapsApplied(tctx, st) {
+		return nil
+	}
+	applied := false
+	for _, fr := range st {
+		if fr.IsSourcemapApplied() {
+			applied = true
+			break
+		}
+	}
+	return &applied
+}
+
+// sourcemapErrorCount returns the number of frames of the transformed
+// stacktrace a sourcemap could not be applied to. It returns nil for empty
+// stacktraces and if sourcemaps are not applied.
+func sourcemapErrorCount(tctx *transform.Context, st m.Stacktrace) *int {
+	if !sourcemapsApplied(tctx, st) {
+		return nil
+	}
+	count := 0
+	for _, fr := range st {
+		if fr.IsSourcemapAttempted() && !fr.IsSourcemapApplied() {
+			count++
+		}
+	}
+	return &count
 }
 
 func findSmappedNonLibraryFrame(frames []*m.StacktraceFrame) *m.StacktraceFrame {
@@ -286,18 +1357,82 @@ func findSmappedNonLibraryFrame(frames []*m.StacktraceFrame) *m.StacktraceFrame
 	return nil
 }
 
+func findNonLibraryFrame(frames []*m.StacktraceFrame) *m.StacktraceFrame {
+	for _, fr := range frames {
+		if !fr.IsLibraryFrame() {
+			return fr
+		}
+	}
+	return nil
+}
+
 func (e *Event) addException(tctx *transform.Context) {
-	if e.Exception == nil {
+	chain := e.exceptionChain()
+	if len(chain) == 0 {
 		return
 	}
+	// error.exception is an array of objects holding each of the elements
+	// of a chained exception, starting with the outermost exception and
+	// ending with the root cause.
+	exceptions := make([]common.MapStr, len(chain))
+	handled := true
+	for idx, exception := range chain {
+		exceptions[idx] = exception.fields(tctx, e.config)
+		if exception.Handled != nil && !*exception.Handled {
+			handled = false
+		}
+	}
+	if e.config.EmitExceptionTypeHierarchy {
+		if types := e.typeHierarchy(chain); len(types) > 0 {
+			exceptions[0]["type_hierarchy"] = types
+		}
+	}
+	e.add("exception", exceptions)
+	// error.handled summarizes the chain: it is only true if no exception
+	// was reported as unhandled
+	e.add("handled", handled)
+}
+
+// typeHierarchy returns the distinct types of the exceptions in the chain,
+// in chain order.
+func (e *Event) typeHierarchy(chain []*Exception) []string {
+	var types []string
+	for _, ex := range chain {
+		if exType := ex.typeName(e.config); exType != nil && !containsString(types, *exType) {
+			types = append(types, *exType)
+		}
+	}
+	return types
+}
+
+// messageHash returns the hex encoded hash of the exception message, if
+// configured, or nil for empty messages.
+func messageHash(msg *string, cfg m.Config) *string {
+	if cfg.ExceptionMessageHash == "" || msg == nil || *msg == "" {
+		return nil
+	}
+	s := *msg
+	if cfg.ExceptionMessageHashNormalize {
+		s = cfg.NormalizeMessage(s)
+	}
+	k := newGroupingKey(cfg.ExceptionMessageHash)
+	defer k.release()
+	k.add(&s)
+	hash := k.String()
+	return &hash
+}
+
+func (e *Exception) fields(tctx *transform.Context, cfg m.Config) common.MapStr {
 	ex := common.MapStr{}
-	utility.Set(ex, "message", e.Exception.Message)
-	utility.Set(ex, "module", e.Exception.Module)
-	utility.Set(ex, "attributes", e.Exception.Attributes)
-	utility.Set(ex, "type", e.Exception.Type)
-	utility.Set(ex, "handled", e.Exception.Handled)
+	utility.Set(ex, "message", redact(e.message(cfg), cfg))
+	utility.Set(ex, "message_hash", messageHash(e.message(cfg), cfg))
+	utility.Set(ex, "module", e.Module)
+	utility.Set(ex, "attributes", promoteAttributes(ex, e.Attributes, cfg))
+	utility.Set(ex, "type", e.typeName(cfg))
+	utility.Set(ex, "handled", e.Handled)
 
-	switch code := e.Exception.Code.(type) {
+	code, category := e.codeParts()
+	switch code := code.(type) {
 	case int:
 		utility.Set(ex, "code", strconv.Itoa(code))
 	case float64:
@@ -306,111 +1441,315 @@ func (e *Event) addException(tctx *transform.Context) {
 		utility.Set(ex, "code", code)
 	case json.Number:
 		utility.Set(ex, "code", code.String())
+	case bool:
+		utility.Set(ex, "code", strconv.FormatBool(code))
+	case nil:
+	default:
+		utility.Set(ex, "code", fmt.Sprintf("%v", code))
+	}
+	utility.Set(ex, "code_category", category)
+	if cfg.EmitNumericExceptionCode {
+		utility.Set(ex, "code_numeric", e.numericCode())
+	}
+
+	if !cfg.OmitStacktraces {
+		utility.Set(ex, "stacktrace", emittedStacktrace(tctx, cfg, e.Stacktrace))
+		utility.Set(ex, "stacktrace_sourcemap_applied", sourcemapApplied(tctx, e.Stacktrace))
+		utility.Set(ex, "stacktrace_sourcemap_error_count", sourcemapErrorCount(tctx, e.Stacktrace))
 	}
+	addStacktraceFrameCount(ex, e.Stacktrace)
+	utility.Set(ex, "stacktrace_frames_omitted", e.FramesOmitted)
+	if causes := e.causeCount(); causes > 0 {
+		ex.Put("cause_count", causes)
+	}
+	return ex
+}
 
-	st := e.Exception.Stacktrace.Transform(tctx)
-	utility.Set(ex, "stacktrace", st)
+// causeCount returns the number of causes of the exception as sent by the
+// agent, or else as set on the exception.
+func (e *Exception) causeCount() int {
+	if e.CauseCount > len(e.Cause) {
+		return e.CauseCount
+	}
+	return len(e.Cause)
+}
 
-	// NOTE(axw) error.exception is an array of objects.
-	// For now, the array holds just one exception. Later,
-	// the array will hold each of the elements of a chained
-	// exception, starting with the outermost exception and
-	// ending with the root cause.
-	e.add("exception", []common.MapStr{ex})
+// customFields returns the custom context restricted to the allowed keys, or
+// nil if it exceeds the configured size limit.
+func (e *Event) customFields(tctx *transform.Context) common.MapStr {
+	custom := e.allowedCustomFields()
+	if e.config.MaxCustomSize <= 0 || len(custom) == 0 {
+		return custom
+	}
+	if b, err := json.Marshal(custom); err != nil || len(b) > e.config.MaxCustomSize {
+		customDropped.Inc()
+		tctx.Log("transform").Warnf("custom context of error dropped, exceeding %d bytes", e.config.MaxCustomSize)
+		return nil
+	}
+	return custom
 }
 
-func (e *Event) addLog(tctx *transform.Context) {
-	if e.Log == nil {
-		return
+// allowedCustomFields returns the custom context without the keys not
+// allowed by the configured allowlist, if any.
+func (e *Event) allowedCustomFields() common.MapStr {
+	custom := e.Custom.Fields()
+	if e.config.CustomKeysAllowlist == nil || len(custom) == 0 {
+		return custom
+	}
+	allowed := common.MapStr{}
+	for key, value := range custom {
+		if containsString(e.config.CustomKeysAllowlist, key) {
+			allowed[key] = value
+		}
+	}
+	customKeysDropped.Add(int64(len(custom) - len(allowed)))
+	return allowed
+}
+
+// exceptionChain returns the flattened chain of exceptions, or nil if the
+// event holds no exception.
+func (e *Event) exceptionChain() []*Exception {
+	if e.Exception == nil {
+		return nil
 	}
-	log := common.MapStr{}
-	utility.Set(log, "message", e.Log.Message)
-	utility.Set(log, "param_message", e.Log.ParamMessage)
-	utility.Set(log, "logger_name", e.Log.LoggerName)
-	utility.Set(log, "level", e.Log.Level)
-	st := e.Log.Stacktrace.Transform(tctx)
-	utility.Set(log, "stacktrace", st)
+	return e.Exception.flatten(maxExceptionCauseDepth(e.config))
+}
 
-	e.add("log", log)
+// recordExceptionChainDepth adds the depth of a transformed exception chain to
+// the monitoring counters.
+func recordExceptionChainDepth(depth int64) {
+	exceptionChainDepthTotal.Add(depth)
+	exceptionChainDepthMu.Lock()
+	if depth > exceptionChainDepthMax.Get() {
+		exceptionChainDepthMax.Set(depth)
+	}
+	exceptionChainDepthMu.Unlock()
 }
 
-func (e *Event) addGroupingKey() {
-	e.add("grouping_key", e.calcGroupingKey())
+// logRecords returns the single log record followed by the records of the
+// logs array.
+func (e *Event) logRecords() []*Log {
+	var logs []*Log
+	if e.Log != nil {
+		logs = append(logs, e.Log)
+	}
+	for i := range e.Logs {
+		logs = append(logs, &e.Logs[i])
+	}
+	return logs
 }
 
-type groupingKey struct {
-	hash  hash.Hash
-	empty bool
+func (e *Event) addLog(tctx *transform.Context) {
+	logs := e.logRecords()
+	switch len(logs) {
+	case 0:
+		return
+	case 1:
+		// keep the single object shape of error.log
+		log := common.MapStr{}
+		logs[0].setFields(log, tctx, e.config)
+		e.add("log", log)
+	default:
+		records := make([]common.MapStr, len(logs))
+		for idx, l := range logs {
+			records[idx] = common.MapStr{}
+			l.setFields(records[idx], tctx, e.config)
+		}
+		e.add("log", records)
+	}
 }
 
-func newGroupingKey() *groupingKey {
-	return &groupingKey{
-		hash:  md5.New(),
-		empty: true,
+func (l *Log) setFields(log common.MapStr, tctx *transform.Context, cfg m.Config) {
+	paramMessage := redact(l.ParamMessage, cfg)
+	utility.Set(log, "message", cfg.Redact(l.Message))
+	utility.Set(log, "param_message", paramMessage)
+	if cfg.EmitLogMessageTemplate {
+		utility.Set(log, "message_template", paramMessage)
+	}
+	utility.Set(log, "logger_name", l.LoggerName)
+	utility.Set(log, "level", l.Level)
+	utility.Set(log, "severity", cfg.LogSeverity(l.Level))
+	if !cfg.OmitStacktraces {
+		utility.Set(log, "stacktrace", emittedStacktrace(tctx, cfg, l.Stacktrace))
+		utility.Set(log, "stacktrace_sourcemap_applied", sourcemapApplied(tctx, l.Stacktrace))
+		utility.Set(log, "stacktrace_sourcemap_error_count", sourcemapErrorCount(tctx, l.Stacktrace))
 	}
+	addStacktraceFrameCount(log, l.Stacktrace)
+	utility.Set(log, "stacktrace_frames_omitted", l.FramesOmitted)
 }
 
-func (k *groupingKey) add(s *string) bool {
-	if s == nil {
+func isHex(s string) bool {
+	if s == "" {
 		return false
 	}
-	io.WriteString(k.hash, *s)
-	k.empty = false
+	for _, c := range s {
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+			return false
+		}
+	}
 	return true
 }
 
-func (k *groupingKey) addEither(s1 *string, s2 string) {
-	if ok := k.add(s1); !ok {
-		k.add(&s2)
+// message returns the exception message, treating empty messages as
+// configured.
+func (e *Exception) message(cfg m.Config) *string {
+	if e.Message == nil || strings.TrimSpace(*e.Message) != "" {
+		return e.Message
+	}
+	if cfg.SubstituteEmptyExceptionMessage {
+		if exType := e.typeName(cfg); exType != nil {
+			return exType
+		}
+	}
+	if cfg.OmitEmptyExceptionMessage {
+		return nil
 	}
+	return e.Message
 }
 
-func (k *groupingKey) String() string {
-	return hex.EncodeToString(k.hash.Sum(nil))
+// numericCode returns the exception code as number if it is a whole number.
+func (e *Exception) numericCode() *int64 {
+	code, _ := e.codeParts()
+	return wholeNumber(code)
 }
 
-// calcGroupingKey computes a value for deduplicating errors - events with
-// same grouping key can be collapsed together.
-func (e *Event) calcGroupingKey() string {
-	k := newGroupingKey()
-
-	var st m.Stacktrace
-	if e.Exception != nil {
-		k.add(e.Exception.Type)
-		st = e.Exception.Stacktrace
+// codeParts splits structured codes, e.g. {"value": 500, "category": "http"},
+// into their value and category. Other codes, including objects without a
+// value, are returned as they are, so the code is never silently lost.
+func (e *Exception) codeParts() (interface{}, *string) {
+	structured, ok := e.Code.(map[string]interface{})
+	if !ok {
+		return e.Code, nil
 	}
-	if e.Log != nil {
-		k.add(e.Log.ParamMessage)
-		if st == nil || len(st) == 0 {
-			st = e.Log.Stacktrace
-		}
+	value := structured["value"]
+	if value == nil {
+		return e.Code, nil
 	}
+	category, _ := structured["category"].(string)
+	if category == "" {
+		return value, nil
+	}
+	return value, &category
+}
 
-	for _, fr := range st {
-		if fr.ExcludeFromGrouping {
-			continue
+// wholeNumber returns v as integer if it is a whole number, else nil.
+func wholeNumber(v interface{}) *int64 {
+	var n int64
+	switch c := v.(type) {
+	case int:
+		n = int64(c)
+	case float64:
+		if c != math.Trunc(c) {
+			return nil
+		}
+		n = int64(c)
+	case json.Number:
+		var err error
+		if n, err = c.Int64(); err != nil {
+			return nil
 		}
-		k.addEither(fr.Module, fr.Filename)
-		k.addEither(fr.Function, string(fr.Lineno))
+	default:
+		return nil
+	}
+	return &n
+}
+
+// exceptionAttributeFields are the fields exception attributes can be
+// promoted to, with functions converting attribute values to the field's
+// type. Conversions return nil for values not convertible.
+var exceptionAttributeFields = map[string]func(interface{}) interface{}{
+	"thread_name":  keywordValue,
+	"thread_id":    keywordValue,
+	"process_name": keywordValue,
+	"process_id":   longValue,
+}
+
+func keywordValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	}
+	if n := wholeNumber(v); n != nil {
+		return strconv.FormatInt(*n, 10)
+	}
+	return nil
+}
+
+func longValue(v interface{}) interface{} {
+	if n := wholeNumber(v); n != nil {
+		return *n
+	}
+	return nil
+}
+
+// promoteAttributes sets the attributes configured to be promoted as typed
+// fields of ex, returning the remaining attributes. Attributes whose value
+// cannot be converted to the field's type are kept as attributes.
+func promoteAttributes(ex common.MapStr, attributes common.MapStr, cfg m.Config) common.MapStr {
+	if len(cfg.PromoteExceptionAttributes) == 0 || len(attributes) == 0 {
+		return attributes
+	}
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
 	}
-	if k.empty {
-		if e.Exception != nil {
-			k.add(e.Exception.Message)
-		} else if e.Log != nil {
-			k.add(&e.Log.Message)
+	sort.Strings(keys)
+	remaining := common.MapStr{}
+	for _, key := range keys {
+		field := cfg.PromoteExceptionAttributes[key]
+		if convert, ok := exceptionAttributeFields[field]; ok {
+			if _, set := ex[field]; !set {
+				if value := convert(attributes[key]); value != nil {
+					ex[field] = value
+					continue
+				}
+			}
 		}
+		remaining[key] = attributes[key]
 	}
+	return remaining
+}
 
-	return k.String()
+// typeName returns the exception type, qualified with the exception module
+// if configured so.
+func (e *Exception) typeName(cfg m.Config) *string {
+	if !cfg.QualifyExceptionType || e.Type == nil || e.Module == nil || *e.Module == "" {
+		return e.Type
+	}
+	if strings.HasPrefix(*e.Type, *e.Module+".") {
+		return e.Type
+	}
+	qualified := *e.Module + "." + *e.Type
+	return &qualified
 }
 
 func (e *Event) add(key string, val interface{}) {
 	utility.Set(e.data, key, val)
 }
 
-func addStacktraceCounter(st m.Stacktrace) {
+func addStacktraceFrameCount(fields common.MapStr, st m.Stacktrace) {
+	if frames := len(st); frames > 0 {
+		fields.Put("stacktrace_frame_count", frames)
+	}
+}
+
+// logTruncatedStacktrace records frames of the given error source's
+// stacktrace dropped for exceeding the maximum number of frames.
+func logTruncatedStacktrace(tctx *transform.Context, source string, framesTruncated int) {
+	if framesTruncated > 0 {
+		tctx.Log("transform").Warnf("%s stacktrace of error truncated, %d frames dropped", source, framesTruncated)
+	}
+}
+
+// addStacktraceCounter counts the stacktrace and its frames, in total and for
+// the given error source.
+func addStacktraceCounter(st m.Stacktrace, sourceStacktraces, sourceFrames *monitoring.Int) {
 	if frames := len(st); frames > 0 {
 		stacktraceCounter.Inc()
 		frameCounter.Add(int64(frames))
+		sourceStacktraces.Inc()
+		sourceFrames.Add(int64(frames))
 	}
 }
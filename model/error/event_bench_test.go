@@ -0,0 +1,177 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package error
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	m "github.com/elastic/apm-server/model"
+	"github.com/elastic/apm-server/transform"
+)
+
+func benchmarkFrames(n int) []*m.StacktraceFrame {
+	frames := make([]*m.StacktraceFrame, n)
+	for i := range frames {
+		fct, module := fmt.Sprintf("function%d", i), "module"
+		frames[i] = &m.StacktraceFrame{
+			Filename: fmt.Sprintf("file%d.go", i),
+			Function: &fct,
+			Module:   &module,
+			Lineno:   i,
+		}
+	}
+	return frames
+}
+
+// benchmarkTransform measures Event.Transform for events created by
+// newEvent. Events are created outside of the timer, as Transform modifies
+// the event.
+func benchmarkTransform(b *testing.B, newEvent func() *Event) {
+	tctx := &transform.Context{}
+	events := make([]*Event, b.N)
+	for i := range events {
+		events[i] = newEvent()
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for _, e := range events {
+		e.Transform(tctx)
+	}
+}
+
+func BenchmarkTransform(b *testing.B) {
+	for _, size := range []struct {
+		name   string
+		frames int
+	}{
+		{"Small", 5},
+		{"Medium", 50},
+		{"Large", 500},
+	} {
+		frames := benchmarkFrames(size.frames)
+		b.Run(size.name, func(b *testing.B) {
+			b.Run("Exception", func(b *testing.B) {
+				benchmarkTransform(b, func() *Event {
+					return &Event{Exception: baseException().withType("type").withFrames(frames)}
+				})
+			})
+			b.Run("Log", func(b *testing.B) {
+				benchmarkTransform(b, func() *Event {
+					return &Event{Log: baseLog().withParamMsg("param message").withFrames(frames)}
+				})
+			})
+			b.Run("ExceptionLogTransaction", func(b *testing.B) {
+				transactionId, traceId, sampled := "945254c567a5417e", "0af7651916cd43dd8448eb211c80319c", true
+				benchmarkTransform(b, func() *Event {
+					return &Event{
+						Exception:          baseException().withType("type").withFrames(frames),
+						Log:                baseLog().withParamMsg("param message").withFrames(frames),
+						TransactionId:      &transactionId,
+						TraceId:            &traceId,
+						TransactionSampled: &sampled,
+					}
+				})
+			})
+		})
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	input := map[string]interface{}{
+		"id":             "0123456789abcdef",
+		"culprit":        "culprit",
+		"timestamp":      json.Number("1496170407154000"),
+		"transaction_id": "945254c567a5417e",
+		"trace_id":       "0af7651916cd43dd8448eb211c80319c",
+		"parent_id":      "b7ad6b7169203331",
+		"transaction":    map[string]interface{}{"sampled": true, "type": "request"},
+		"exception": map[string]interface{}{
+			"message": "exception message",
+			"type":    "type",
+			"stacktrace": []interface{}{
+				map[string]interface{}{"filename": "file", "lineno": 1.0, "function": "function"},
+			},
+		},
+		"log": map[string]interface{}{"message": "log message", "param_message": "param message"},
+	}
+	b.Run("DecodeEvent", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeEvent(input, m.Config{}, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("DecodeEventInto", func(b *testing.B) {
+		var e Event
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := DecodeEventInto(&e, input, m.Config{}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTransformDisableGroupingKey(b *testing.B) {
+	frames := benchmarkFrames(50)
+	for name, cfg := range map[string]m.Config{
+		"WithGroupingKey":    {},
+		"WithoutGroupingKey": {DisableGroupingKey: true},
+	} {
+		cfg := cfg
+		b.Run(name, func(b *testing.B) {
+			benchmarkTransform(b, func() *Event {
+				return &Event{Exception: baseException().withType("type").withFrames(frames), config: cfg}
+			})
+		})
+	}
+}
+
+func BenchmarkTransformOmitStacktraces(b *testing.B) {
+	frames := benchmarkFrames(50)
+	for name, cfg := range map[string]m.Config{
+		"WithStacktraces":    {},
+		"WithoutStacktraces": {OmitStacktraces: true},
+	} {
+		cfg := cfg
+		b.Run(name, func(b *testing.B) {
+			benchmarkTransform(b, func() *Event {
+				return &Event{Exception: baseException().withType("type").withFrames(frames), config: cfg}
+			})
+		})
+	}
+}
+
+func BenchmarkGroupingKey(b *testing.B) {
+	e := &Event{Exception: baseException().withType("type").withFrames(benchmarkFrames(10))}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		e.calcGroupingKey()
+	}
+}
+
+func BenchmarkSize(b *testing.B) {
+	e := &Event{Exception: baseException().withType("type").withFrames(benchmarkFrames(50))}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		e.Size()
+	}
+}
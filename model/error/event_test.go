@@ -18,28 +18,45 @@
 package error
 
 import (
+	"bytes"
+	"compress/gzip"
 	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"text/template"
 	"time"
 
 	s "github.com/go-sourcemap/sourcemap"
+	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	m "github.com/elastic/apm-server/model"
 	"github.com/elastic/apm-server/model/metadata"
 	"github.com/elastic/apm-server/sourcemap"
 	"github.com/elastic/apm-server/transform"
+	"github.com/elastic/apm-server/utility"
 	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
 )
 
 func baseException() *Exception {
@@ -57,6 +74,11 @@ func (e *Exception) withType(etype string) *Exception {
 	return e
 }
 
+func (e *Exception) withModule(module string) *Exception {
+	e.Module = &module
+	return e
+}
+
 func (e *Exception) withFrames(frames []*m.StacktraceFrame) *Exception {
 	e.Stacktrace = m.Stacktrace(frames)
 	return e
@@ -84,7 +106,8 @@ func TestErrorEventDecode(t *testing.T) {
 	parentId, traceId, transactionId := "0123456789abcdef", "01234567890123456789abcdefabcdef", "abcdefabcdef0000"
 	name, userId, email, userIp := "jane", "abc123", "j@d.com", "127.0.0.1"
 	pUrl, referer, origUrl := "https://mypage.com", "http:mypage.com", "127.0.0.1"
-	code, module, attrs, exType, handled := "200", "a", "attr", "errorEx", false
+	code, module, exType, handled := "200", "a", "errorEx", false
+	attrs := map[string]interface{}{"k1": "val1"}
 	exMsg, paramMsg, level, logger := "Exception Msg", "log pm", "error", "mylogger"
 	transactionSampled := true
 	transactionType := "request"
@@ -97,6 +120,8 @@ func TestErrorEventDecode(t *testing.T) {
 	response := m.Resp{Finished: new(bool), Headers: http.Header{"Content-Type": []string{"text/html"}}}
 	h := m.Http{Request: &request, Response: &response}
 	ctxUrl := m.Url{Original: &origUrl}
+	outerMsg, middleType, rootMsg := "outer", "middle", "root"
+	groupingKey := "dc9ed07b49de3c9D15"
 
 	for name, test := range map[string]struct {
 		input       interface{}
@@ -109,22 +134,22 @@ func TestErrorEventDecode(t *testing.T) {
 		"invalid type":        {input: "", err: errors.New("invalid type for error event"), e: nil},
 		"error decoding timestamp": {
 			input: map[string]interface{}{"timestamp": 123},
-			err:   errors.New("Error fetching field"),
+			err:   errors.New("error.timestamp: expected integer, got number"),
 		},
 		"error decoding transaction id": {
 			input: map[string]interface{}{"transaction_id": 123},
-			err:   errors.New("Error fetching field"),
+			err:   errors.New("error.transaction_id: expected string, got number"),
 		},
 		"only parent id given": {input: map[string]interface{}{
 			"id": id, "culprit": culprit, "context": map[string]interface{}{}, "timestamp": timestamp,
 			"parent_id": 123},
-			err: errors.New("Error fetching field"),
+			err: errors.New("error.parent_id: expected string, got number"),
 		},
 		"only trace id given": {
 			input: map[string]interface{}{
 				"id": id, "culprit": culprit, "context": map[string]interface{}{}, "timestamp": timestamp,
 				"trace_id": 123},
-			err: errors.New("Error fetching field"),
+			err: errors.New("error.trace_id: expected string, got number"),
 		},
 		"invalid type for exception stacktrace": {
 			input: map[string]interface{}{
@@ -134,7 +159,20 @@ func TestErrorEventDecode(t *testing.T) {
 					"stacktrace": "123",
 				},
 			},
-			err: errors.New("invalid type for stacktrace"),
+			err: errors.New("error.exception.stacktrace: expected array, got string"),
+		},
+		"invalid type for exception stacktrace frame field": {
+			input: map[string]interface{}{
+				"timestamp": timestamp,
+				"exception": map[string]interface{}{
+					"message": "Exception Msg",
+					"stacktrace": []interface{}{
+						map[string]interface{}{"filename": "file", "lineno": 1.0},
+						map[string]interface{}{"filename": "file", "lineno": "1"},
+					},
+				},
+			},
+			err: errors.New("error.exception.stacktrace[1].lineno: expected integer, got string"),
 		},
 		"invalid type for log stacktrace": {
 			input: map[string]interface{}{
@@ -144,7 +182,14 @@ func TestErrorEventDecode(t *testing.T) {
 					"stacktrace": "123",
 				},
 			},
-			err: errors.New("invalid type for stacktrace"),
+			err: errors.New("error.log.stacktrace: expected array, got string"),
+		},
+		"invalid type for log message": {
+			input: map[string]interface{}{
+				"timestamp": timestamp,
+				"log":       map[string]interface{}{"message": 1.0},
+			},
+			err: errors.New("error.log.message: expected string, got number"),
 		},
 		"minimal valid error": {
 			input: map[string]interface{}{
@@ -155,6 +200,122 @@ func TestErrorEventDecode(t *testing.T) {
 				Timestamp: timestampParsed,
 			},
 		},
+		"exception with object code": {
+			input: map[string]interface{}{"timestamp": timestamp, "exception": map[string]interface{}{
+				"message": exMsg, "code": map[string]interface{}{"value": json.Number("500"), "category": "http"}}},
+			e: &Event{
+				Timestamp: timestampParsed,
+				Exception: &Exception{
+					Message:    &exMsg,
+					Code:       map[string]interface{}{"value": json.Number("500"), "category": "http"},
+					Stacktrace: m.Stacktrace{},
+				},
+			},
+		},
+		"grouping key given": {
+			input: map[string]interface{}{"timestamp": timestamp, "grouping_key": "dc9ed07b49de3c9D15"},
+			e:     &Event{Timestamp: timestampParsed, GroupingKey: &groupingKey},
+		},
+		"invalid grouping key": {
+			input: map[string]interface{}{"timestamp": timestamp, "grouping_key": "not-a-key"},
+			err:   errors.New("error.grouping_key: expected non-empty hex string"),
+		},
+		"empty grouping key": {
+			input: map[string]interface{}{"timestamp": timestamp, "grouping_key": ""},
+			err:   errors.New("error.grouping_key: expected non-empty hex string"),
+		},
+		"transaction id without trace id": {
+			input: map[string]interface{}{"timestamp": timestamp, "transaction_id": transactionId},
+			e:     &Event{Timestamp: timestampParsed, TransactionId: &transactionId},
+		},
+		"transaction id without trace id, trace id required": {
+			input: map[string]interface{}{"timestamp": timestamp, "transaction_id": transactionId},
+			cfg:   m.Config{RequireTraceId: true},
+			err:   errors.New("error.trace_id: required when error.transaction_id is set"),
+		},
+		"transaction id with trace id, trace id required": {
+			input: map[string]interface{}{"timestamp": timestamp, "transaction_id": transactionId, "trace_id": traceId},
+			cfg:   m.Config{RequireTraceId: true},
+			e: &Event{
+				Timestamp:     timestampParsed,
+				TransactionId: &transactionId,
+				TraceId:       &traceId,
+				config:        m.Config{RequireTraceId: true},
+			},
+		},
+		"invalid type for exception cause": {
+			input: map[string]interface{}{
+				"timestamp": timestamp,
+				"exception": map[string]interface{}{
+					"message": "Exception Msg",
+					"cause":   []interface{}{"123"},
+				},
+			},
+			err: errors.New("error.exception.cause[0]: expected object, got string"),
+		},
+		"chained exception": {
+			input: map[string]interface{}{
+				"timestamp": timestamp,
+				"exception": map[string]interface{}{
+					"message": "outer",
+					"cause": []interface{}{
+						map[string]interface{}{
+							"type": "middle",
+							"cause": []interface{}{
+								map[string]interface{}{"message": "root"},
+								map[string]interface{}{},
+							},
+						},
+					},
+				},
+			},
+			e: &Event{
+				Timestamp: timestampParsed,
+				Exception: &Exception{
+					Message:    &outerMsg,
+					Stacktrace: m.Stacktrace{},
+					CauseCount: 1,
+					Cause: []Exception{{
+						Type:       &middleType,
+						Stacktrace: m.Stacktrace{},
+						CauseCount: 2,
+						Cause: []Exception{{
+							Message:    &rootMsg,
+							Stacktrace: m.Stacktrace{},
+						}},
+					}},
+				},
+			},
+		},
+		"chained exception exceeding max depth": {
+			input: map[string]interface{}{
+				"timestamp": timestamp,
+				"exception": map[string]interface{}{
+					"message": "outer",
+					"cause": []interface{}{
+						map[string]interface{}{
+							"type":  "middle",
+							"cause": []interface{}{map[string]interface{}{"message": "root"}},
+						},
+					},
+				},
+			},
+			e: &Event{
+				Timestamp: timestampParsed,
+				Exception: &Exception{
+					Message:    &outerMsg,
+					Stacktrace: m.Stacktrace{},
+					CauseCount: 1,
+					Cause: []Exception{{
+						Type:       &middleType,
+						Stacktrace: m.Stacktrace{},
+						CauseCount: 1,
+					}},
+				},
+				config: m.Config{MaxExceptionCauseDepth: 2},
+			},
+			cfg: m.Config{MaxExceptionCauseDepth: 2},
+		},
 		"minimal valid error with log and exception": {
 			input: map[string]interface{}{
 				"timestamp": timestamp,
@@ -173,6 +334,7 @@ func TestErrorEventDecode(t *testing.T) {
 				Id:        &id,
 				Culprit:   &culprit,
 				Timestamp: timestampParsed,
+				config:    m.Config{Experimental: true},
 			},
 			cfg: m.Config{Experimental: true},
 		},
@@ -196,6 +358,7 @@ func TestErrorEventDecode(t *testing.T) {
 				Culprit:      &culprit,
 				Timestamp:    timestampParsed,
 				Experimental: []string{"a", "b"},
+				config:       m.Config{Experimental: true},
 			},
 			cfg: m.Config{Experimental: true},
 		},
@@ -255,7 +418,7 @@ func TestErrorEventDecode(t *testing.T) {
 					Code:       code,
 					Type:       &exType,
 					Module:     &module,
-					Attributes: attrs,
+					Attributes: common.MapStr(attrs),
 					Handled:    &handled,
 					Stacktrace: m.Stacktrace{
 						&m.StacktraceFrame{Filename: "file", Lineno: 1},
@@ -285,434 +448,3720 @@ func TestErrorEventDecode(t *testing.T) {
 				assert.Equal(t, test.e, event)
 			}
 
-			assert.Equal(t, test.err, err)
+			if test.err != nil {
+				assert.EqualError(t, err, test.err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
 		})
 	}
 }
 
-func TestEventFields(t *testing.T) {
-	id := "45678"
-	culprit := "some trigger"
+func TestDecodeTimestampSkew(t *testing.T) {
+	now := time.Now()
+	micros := func(ts time.Time) json.Number {
+		return json.Number(strconv.FormatInt(ts.UnixNano()/1000, 10))
+	}
+	inRange, tooOld, tooNew := now.Add(-time.Minute), now.Add(-48*time.Hour), now.Add(48*time.Hour)
+	skew := m.Config{MaxTimestampPastSkew: 24 * time.Hour, MaxTimestampFutureSkew: time.Hour}
+	clamp := skew
+	clamp.ClampTimestamps = true
 
-	errorType := "error type"
-	codeFloat := 13.0
-	module := "error module"
-	exMsg := "exception message"
-	handled := false
-	attributes := common.MapStr{"k1": "val1"}
-	exception := Exception{
-		Type:       &errorType,
-		Code:       codeFloat,
-		Message:    &exMsg,
-		Module:     &module,
-		Handled:    &handled,
-		Attributes: attributes,
-		Stacktrace: []*m.StacktraceFrame{{Filename: "st file"}},
+	for name, test := range map[string]struct {
+		timestamp time.Time
+		cfg       m.Config
+		err       string
+		clamped   bool
+	}{
+		"noBounds":      {timestamp: tooOld},
+		"inRange":       {timestamp: inRange, cfg: skew},
+		"tooOld":        {timestamp: tooOld, cfg: skew, err: "is more than 24h0m0s in the past"},
+		"tooNew":        {timestamp: tooNew, cfg: skew, err: "is more than 1h0m0s in the future"},
+		"inRangeClamp":  {timestamp: inRange, cfg: clamp},
+		"tooOldClamped": {timestamp: tooOld, cfg: clamp, clamped: true},
+		"tooNewClamped": {timestamp: tooNew, cfg: clamp, clamped: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			input := map[string]interface{}{"timestamp": micros(test.timestamp)}
+			transformable, err := DecodeEvent(input, test.cfg, nil)
+			if test.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.err)
+				return
+			}
+			require.NoError(t, err)
+			requestTime := now.Add(time.Second)
+			event := transformable.Transform(&transform.Context{RequestTime: requestTime})[0]
+			if test.clamped {
+				assert.Equal(t, requestTime, event.Timestamp)
+			} else {
+				assert.Equal(t, test.timestamp.UnixNano()/1000, event.Timestamp.UnixNano()/1000)
+			}
+		})
 	}
+}
 
-	level := "level"
-	loggerName := "logger"
-	logMsg := "error log message"
-	paramMsg := "param message"
-	log := Log{
-		Level:        &level,
-		Message:      logMsg,
-		ParamMessage: &paramMsg,
-		LoggerName:   &loggerName,
+func TestDecodeTimestampFormats(t *testing.T) {
+	expected := time.Date(2017, 5, 30, 18, 53, 27, 154000000, time.UTC)
+	for name, test := range map[string]struct {
+		timestamp interface{}
+		expected  time.Time
+		err       string
+	}{
+		"epochMicros":    {timestamp: json.Number("1496170407154000"), expected: expected},
+		"rfc3339":        {timestamp: "2017-05-30T18:53:27.154Z", expected: expected},
+		"rfc3339Offset":  {timestamp: "2017-05-30T20:53:27.154+02:00", expected: expected},
+		"rfc3339Seconds": {timestamp: "2017-05-30T18:53:27Z", expected: expected.Truncate(time.Second)},
+		"garbageString":  {timestamp: "yesterday", err: "error.timestamp: expected integer, got string"},
+		"garbageType":    {timestamp: true, err: "error.timestamp: expected integer, got boolean"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			input := map[string]interface{}{"timestamp": test.timestamp}
+			transformable, err := DecodeEvent(input, m.Config{}, nil)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, transformable.(*Event).Timestamp)
+		})
 	}
-	baseExceptionHash := md5.New()
-	io.WriteString(baseExceptionHash, *baseException().Message)
-	// 706a38d554b47b8f82c6b542725c05dc
-	baseExceptionGroupingKey := hex.EncodeToString(baseExceptionHash.Sum(nil))
+}
 
-	baseLogHash := md5.New()
-	io.WriteString(baseLogHash, baseLog().Message)
-	baseLogGroupingKey := hex.EncodeToString(baseLogHash.Sum(nil))
-	trId := "945254c5-67a5-417e-8a4e-aa29efcbfb79"
+func TestDecodeTimestampDetectSeconds(t *testing.T) {
+	expected := time.Date(2020, 8, 9, 19, 6, 40, 123456000, time.UTC)
+	for name, test := range map[string]struct {
+		timestamp interface{}
+		cfg       m.Config
+		expected  time.Time
+		err       string
+	}{
+		"micros":               {timestamp: json.Number("1597000000123456"), expected: expected},
+		"microsDetect":         {timestamp: json.Number("1597000000123456"), cfg: m.Config{TimestampDetectSeconds: true}, expected: expected},
+		"secondsFloat":         {timestamp: json.Number("1597000000.123456"), cfg: m.Config{TimestampDetectSeconds: true}, expected: expected},
+		"secondsInt":           {timestamp: json.Number("1597000000"), cfg: m.Config{TimestampDetectSeconds: true}, expected: expected.Truncate(time.Second)},
+		"secondsMillis":        {timestamp: json.Number("1597000000.5"), cfg: m.Config{TimestampDetectSeconds: true}, expected: expected.Truncate(time.Second).Add(500 * time.Millisecond)},
+		"secondsFloat64":       {timestamp: 1597000000.123456, cfg: m.Config{TimestampDetectSeconds: true}, expected: expected},
+		"secondsIntNoDetect":   {timestamp: json.Number("1597000000"), expected: time.Unix(1597, 0).UTC()},
+		"secondsFloatNoDetect": {timestamp: json.Number("1597000000.123456"), err: "error.timestamp: expected integer, got number"},
+		"rfc3339Detect":        {timestamp: "2020-08-09T19:06:40.123456Z", cfg: m.Config{TimestampDetectSeconds: true}, expected: expected},
+	} {
+		t.Run(name, func(t *testing.T) {
+			input := map[string]interface{}{"timestamp": test.timestamp}
+			transformable, err := DecodeEvent(input, test.cfg, nil)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, transformable.(*Event).Timestamp)
+		})
+	}
+}
 
-	tests := []struct {
-		Event  Event
-		Output common.MapStr
-		Msg    string
+func TestDecodeTimestampFallback(t *testing.T) {
+	input := map[string]interface{}{"timestamp": "invalid", "id": "123"}
+
+	_, err := DecodeEvent(input, m.Config{}, nil)
+	assert.EqualError(t, err, "error.timestamp: expected integer, got string")
+
+	before := timestampFallback.Get()
+	transformable, err := DecodeEvent(input, m.Config{TimestampFallback: true}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, before+1, timestampFallback.Get())
+	requestTime := time.Now()
+	event := transformable.Transform(&transform.Context{RequestTime: requestTime})[0]
+	assert.Equal(t, requestTime, event.Timestamp)
+
+	// other decoding errors are still returned
+	input["id"] = 123
+	_, err = DecodeEvent(input, m.Config{TimestampFallback: true}, nil)
+	assert.EqualError(t, err, "error.id: expected string, got number")
+}
+
+func TestDecodeStacktraceErrorContext(t *testing.T) {
+	invalidFrames := []interface{}{map[string]interface{}{"filename": "file", "lineno": "1"}}
+	for name, test := range map[string]struct {
+		input map[string]interface{}
+		path  string
 	}{
-		{
-			Event: Event{},
-			Output: common.MapStr{
-				"grouping_key": hex.EncodeToString(md5.New().Sum(nil)),
-			},
-			Msg: "Minimal Event",
-		},
-		{
-			Event: Event{Log: baseLog()},
-			Output: common.MapStr{
-				"log":          common.MapStr{"message": "error log message"},
-				"grouping_key": baseLogGroupingKey,
-			},
-			Msg: "Minimal Event wth log",
-		},
-		{
-			Event: Event{Exception: baseException(), Log: baseLog()},
-			Output: common.MapStr{
-				"exception":    []common.MapStr{{"message": "exception message"}},
-				"log":          common.MapStr{"message": "error log message"},
-				"grouping_key": baseExceptionGroupingKey,
-			},
-			Msg: "Minimal Event wth log and exception",
-		},
-		{
-			Event: Event{Exception: baseException()},
-			Output: common.MapStr{
-				"exception":    []common.MapStr{{"message": "exception message"}},
-				"grouping_key": baseExceptionGroupingKey,
-			},
-			Msg: "Minimal Event with exception",
+		"exception": {
+			input: map[string]interface{}{"exception": map[string]interface{}{"message": "msg", "stacktrace": "123"}},
+			path:  "error.exception.stacktrace",
 		},
-		{
-			Event: Event{Exception: baseException().withCode("13")},
-			Output: common.MapStr{
-				"exception":    []common.MapStr{{"message": "exception message", "code": "13"}},
-				"grouping_key": baseExceptionGroupingKey,
-			},
-			Msg: "Minimal Event with exception and string code",
+		"exceptionFrame": {
+			input: map[string]interface{}{"exception": map[string]interface{}{"message": "msg", "stacktrace": invalidFrames}},
+			path:  "error.exception.stacktrace[0].lineno",
 		},
-		{
-			Event: Event{Exception: baseException().withCode(13)},
-			Output: common.MapStr{
-				"exception":    []common.MapStr{{"message": "exception message", "code": "13"}},
-				"grouping_key": baseExceptionGroupingKey,
-			},
-			Msg: "Minimal Event wth exception and int code",
+		"exceptionCause": {
+			input: map[string]interface{}{"exception": map[string]interface{}{
+				"message": "msg",
+				"cause":   []interface{}{map[string]interface{}{"message": "cause", "stacktrace": "123"}},
+			}},
+			path: "error.exception.cause[0].stacktrace",
 		},
-		{
-			Event: Event{Exception: baseException().withCode(13.0)},
-			Output: common.MapStr{
-				"exception":    []common.MapStr{{"message": "exception message", "code": "13"}},
-				"grouping_key": baseExceptionGroupingKey,
-			},
-			Msg: "Minimal Event wth exception and float code",
+		"log": {
+			input: map[string]interface{}{"log": map[string]interface{}{"message": "msg", "stacktrace": "123"}},
+			path:  "error.log.stacktrace",
 		},
-		{
-			Event: Event{
-				Id:            &id,
-				Timestamp:     time.Now(),
-				Culprit:       &culprit,
-				Exception:     &exception,
-				Log:           &log,
-				TransactionId: &trId,
-			},
-			Output: common.MapStr{
-				"id":      "45678",
-				"culprit": "some trigger",
-				"exception": []common.MapStr{{
-					"stacktrace": []common.MapStr{{
-						"filename":              "st file",
-						"line":                  common.MapStr{"number": 0},
-						"exclude_from_grouping": false,
-						"sourcemap": common.MapStr{
-							"error":   "Colno mandatory for sourcemapping.",
-							"updated": false,
-						},
-					}},
-					"code":       "13",
-					"message":    "exception message",
-					"module":     "error module",
-					"attributes": common.MapStr{"k1": "val1"},
-					"type":       "error type",
-					"handled":    false,
-				}},
-				"log": common.MapStr{
-					"message":       "error log message",
-					"param_message": "param message",
-					"logger_name":   "logger",
-					"level":         "level",
-				},
-				"grouping_key": "d47ca09e1cfd512804f5d55cecd34262",
-			},
-			Msg: "Event with frames",
+		"logFrame": {
+			input: map[string]interface{}{"log": map[string]interface{}{"message": "msg", "stacktrace": invalidFrames}},
+			path:  "error.log.stacktrace[0].lineno",
 		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := DecodeEvent(test.input, m.Config{}, nil)
+			require.Error(t, err)
+			assert.True(t, strings.HasPrefix(err.Error(), test.path+": "), err.Error())
+			var fieldErr *utility.FieldError
+			require.True(t, errors.As(err, &fieldErr))
+			assert.Equal(t, test.path, fieldErr.Path)
+		})
 	}
+}
 
-	s := "myservice"
-	tctx := &transform.Context{
-		Config: transform.Config{SmapMapper: &sourcemap.SmapMapper{}},
-		Metadata: metadata.Metadata{
-			Service: &metadata.Service{Name: &s},
-		},
+func TestDecodeRequireExceptionOrLog(t *testing.T) {
+	exception := map[string]interface{}{"message": "exception message"}
+	log := map[string]interface{}{"message": "log message"}
+	for name, test := range map[string]struct {
+		input  map[string]interface{}
+		strict bool
+		err    string
+	}{
+		"empty":                 {input: map[string]interface{}{}},
+		"emptyStrict":           {input: map[string]interface{}{}, strict: true, err: "error: requires an exception or a log"},
+		"exceptionOnlyStrict":   {input: map[string]interface{}{"exception": exception}, strict: true},
+		"logOnlyStrict":         {input: map[string]interface{}{"log": log}, strict: true},
+		"exceptionAndLogStrict": {input: map[string]interface{}{"exception": exception, "log": log}, strict: true},
+		"emptyExceptionStrict":  {input: map[string]interface{}{"exception": map[string]interface{}{}}, strict: true, err: "error: requires an exception or a log"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := DecodeEvent(test.input, m.Config{RequireExceptionOrLog: test.strict}, nil)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
 	}
+}
 
-	for idx, test := range tests {
-		output := test.Event.Transform(tctx)
-		require.Len(t, output, 1)
-		fields := output[0].Fields["error"]
-		assert.Equal(t, test.Output, fields, fmt.Sprintf("Failed at idx %v; %s", idx, test.Msg))
+func TestDecodeLenientBool(t *testing.T) {
+	input := func(v interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"exception":      map[string]interface{}{"message": "exception message", "handled": v},
+			"transaction_id": "945254c567a5417e",
+			"transaction":    map[string]interface{}{"sampled": v},
+		}
+	}
+	lenient := m.Config{LenientBoolDecoding: true}
+	isTrue, isFalse := true, false
+	for name, test := range map[string]struct {
+		value    interface{}
+		cfg      m.Config
+		expected *bool
+		err      string
+	}{
+		"nativeTrue":     {value: true, expected: &isTrue},
+		"nativeFalse":    {value: false, cfg: lenient, expected: &isFalse},
+		"stringStrict":   {value: "false", err: "expected boolean, got string"},
+		"stringTrue":     {value: "true", cfg: lenient, expected: &isTrue},
+		"stringFalse":    {value: "false", cfg: lenient, expected: &isFalse},
+		"stringOne":      {value: "1", cfg: lenient, expected: &isTrue},
+		"stringZero":     {value: "0", cfg: lenient, expected: &isFalse},
+		"invalidString":  {value: "no", cfg: lenient, err: "error.transaction.sampled: expected boolean, got string"},
+		"upperCaseFalse": {value: "FALSE", cfg: lenient, err: "error.transaction.sampled: expected boolean, got string"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			transformable, err := DecodeEvent(input(test.value), test.cfg, nil)
+			if test.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.err)
+				return
+			}
+			require.NoError(t, err)
+			event := transformable.(*Event)
+			assert.Equal(t, test.expected, event.Exception.Handled)
+			assert.Equal(t, test.expected, event.TransactionSampled)
+		})
 	}
 }
 
-func TestEvents(t *testing.T) {
+func TestDecodeExceptionType(t *testing.T) {
+	valueError, unicodeDecodeError := "ValueError", "UnicodeDecodeError"
+	for name, test := range map[string]struct {
+		exType   interface{}
+		expected *string
+		err      string
+	}{
+		"string":  {exType: "ValueError", expected: &valueError},
+		"array":   {exType: []interface{}{"UnicodeDecodeError", "UnicodeError", "ValueError"}, expected: &unicodeDecodeError},
+		"single":  {exType: []interface{}{"ValueError"}, expected: &valueError},
+		"empty":   {exType: []interface{}{}},
+		"absent":  {},
+		"invalid": {exType: 123.0, err: "error.exception.type: expected string, got number"},
+		"invalidElement": {
+			exType: []interface{}{123.0, "ValueError"},
+			err:    "error.exception.type[0]: expected string, got number",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			exception := map[string]interface{}{"message": "exception message"}
+			if test.exType != nil {
+				exception["type"] = test.exType
+			}
+			transformable, err := DecodeEvent(map[string]interface{}{"exception": exception}, m.Config{}, nil)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			event := transformable.(*Event)
+			assert.Equal(t, test.expected, event.Exception.Type)
 
-	timestamp, _ := time.Parse(time.RFC3339, "2019-01-03T15:17:04.908596+01:00")
-	timestampUs := timestamp.UnixNano() / 1000
-	serviceName, agentName, version := "myservice", "go", "1.0"
-	service := metadata.Service{
-		Name: &serviceName, Agent: metadata.Agent{Name: &agentName, Version: &version},
+			exceptions, err := event.Document(nil).GetValue("error.exception")
+			require.NoError(t, err)
+			emitted, ok := exceptions.([]common.MapStr)[0]["type"]
+			assert.Equal(t, test.expected != nil, ok)
+			if ok {
+				assert.Equal(t, *test.expected, emitted)
+			}
+		})
 	}
-	exMsg := "exception message"
-	trId := "945254c5-67a5-417e-8a4e-aa29efcbfb79"
-	sampledTrue, sampledFalse := true, false
-	transactionType := "request"
 
-	email, userIp, userAgent := "m@m.com", "127.0.0.1", "js-1.0"
-	uid := "1234567889"
-	url, referer := "https://localhost", "http://localhost"
-	labels := m.Labels(common.MapStr{"key": true})
-	custom := m.Custom(common.MapStr{"foo": "bar"})
-	metadataLabels := common.MapStr{"label": 101}
-	tests := []struct {
-		Transformable transform.Transformable
-		Output        common.MapStr
-		Msg           string
+	// both forms group identically
+	decode := func(exType interface{}) *Event {
+		input := map[string]interface{}{"exception": map[string]interface{}{"message": "msg", "type": exType}}
+		transformable, err := DecodeEvent(input, m.Config{}, nil)
+		require.NoError(t, err)
+		return transformable.(*Event)
+	}
+	assert.Equal(t,
+		decode("UnicodeDecodeError").calcGroupingKey(),
+		decode([]interface{}{"UnicodeDecodeError", "ValueError"}).calcGroupingKey())
+}
+
+func TestDecodeExceptionAttributes(t *testing.T) {
+	for name, test := range map[string]struct {
+		attributes interface{}
+		lenient    bool
+		expected   common.MapStr
+		err        string
+		dropped    int64
 	}{
-		{
-			Transformable: &Event{Timestamp: timestamp},
-			Output: common.MapStr{
-				"agent":   common.MapStr{"name": "go", "version": "1.0"},
-				"service": common.MapStr{"name": "myservice"},
-				"error": common.MapStr{
-					"grouping_key": "d41d8cd98f00b204e9800998ecf8427e",
-				},
-				"user":      common.MapStr{"id": uid},
-				"processor": common.MapStr{"event": "error", "name": "error"},
-				"timestamp": common.MapStr{"us": timestampUs},
-				"labels":    common.MapStr{"label": 101},
+		"absent": {},
+		"map": {
+			attributes: map[string]interface{}{"k1": "val1"},
+			expected:   common.MapStr{"k1": "val1"},
+		},
+		"scalar": {
+			attributes: "attr",
+			err:        "error.exception.attributes: expected object, got string",
+		},
+		"array": {
+			attributes: []interface{}{"attr"},
+			err:        "error.exception.attributes: expected object, got array",
+		},
+		"scalarLenient": {attributes: 123.0, lenient: true, dropped: 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			exception := map[string]interface{}{"message": "exception message"}
+			if test.attributes != nil {
+				exception["attributes"] = test.attributes
+			}
+			before := attributesDropped.Get()
+			cfg := m.Config{DropInvalidExceptionAttributes: test.lenient}
+			transformable, err := DecodeEvent(map[string]interface{}{"exception": exception}, cfg, nil)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			event := transformable.(*Event)
+			assert.Equal(t, test.expected, event.Exception.Attributes)
+			assert.Equal(t, test.dropped, attributesDropped.Get()-before)
+
+			exceptions, err := event.Document(nil).GetValue("error.exception")
+			require.NoError(t, err)
+			attrs, ok := exceptions.([]common.MapStr)[0]["attributes"]
+			assert.Equal(t, test.expected != nil, ok)
+			if ok {
+				assert.Equal(t, test.expected, attrs)
+			}
+		})
+	}
+}
+
+func TestDecodeDefaultTransactionSampled(t *testing.T) {
+	trId, sampled, unsampled := "945254c5-67a5-417e-8a4e-aa29efcbfb79", true, false
+	for name, test := range map[string]struct {
+		transactionId interface{}
+		sampled       interface{}
+		enabled       bool
+		expected      *bool
+	}{
+		"noTransaction":               {},
+		"noTransactionEnabled":        {enabled: true},
+		"emptyTransactionIdEnabled":   {transactionId: "", enabled: true},
+		"transactionId":               {transactionId: trId},
+		"transactionIdEnabled":        {transactionId: trId, enabled: true, expected: &sampled},
+		"sampledEnabled":              {transactionId: trId, sampled: true, enabled: true, expected: &sampled},
+		"unsampled":                   {transactionId: trId, sampled: false, expected: &unsampled},
+		"unsampledEnabled":            {transactionId: trId, sampled: false, enabled: true, expected: &unsampled},
+		"sampledWithoutTransactionId": {sampled: true, enabled: true, expected: &sampled},
+	} {
+		t.Run(name, func(t *testing.T) {
+			input := map[string]interface{}{}
+			if test.transactionId != nil {
+				input["transaction_id"] = test.transactionId
+			}
+			if test.sampled != nil {
+				input["transaction"] = map[string]interface{}{"sampled": test.sampled}
+			}
+			transformable, err := DecodeEvent(input, m.Config{DefaultTransactionSampled: test.enabled}, nil)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, transformable.(*Event).TransactionSampled)
+		})
+	}
+}
+
+func TestDecodeLogsArray(t *testing.T) {
+	log := map[string]interface{}{"message": "log message"}
+	first := map[string]interface{}{"message": "first", "level": "warn"}
+	second := map[string]interface{}{"message": "second"}
+	warn := "warn"
+	for name, test := range map[string]struct {
+		input    map[string]interface{}
+		disabled bool
+		logs     []Log
+		output   interface{}
+		err      string
+	}{
+		"singleObject": {
+			input:  map[string]interface{}{"log": log},
+			output: common.MapStr{"message": "log message"},
+		},
+		"emptyArray": {
+			input: map[string]interface{}{"exception": map[string]interface{}{"message": "ex"}, "logs": []interface{}{}},
+		},
+		"singleElementArray": {
+			input:  map[string]interface{}{"logs": []interface{}{second}},
+			logs:   []Log{{Message: "second", Stacktrace: m.Stacktrace{}}},
+			output: common.MapStr{"message": "second"},
+		},
+		"array": {
+			input: map[string]interface{}{"logs": []interface{}{first, second}},
+			logs: []Log{
+				{Message: "first", Level: &warn, Stacktrace: m.Stacktrace{}},
+				{Message: "second", Stacktrace: m.Stacktrace{}},
 			},
-			Msg: "Payload with valid Event.",
+			output: []common.MapStr{{"message": "first", "level": "warn"}, {"message": "second"}},
 		},
-		{
-			Transformable: &Event{Timestamp: timestamp, TransactionSampled: &sampledFalse},
-			Output: common.MapStr{
-				"transaction": common.MapStr{"sampled": false},
-				"agent":       common.MapStr{"name": "go", "version": "1.0"},
-				"service":     common.MapStr{"name": "myservice"},
-				"error": common.MapStr{
-					"grouping_key": "d41d8cd98f00b204e9800998ecf8427e",
-				},
-				"user":      common.MapStr{"id": uid},
-				"processor": common.MapStr{"event": "error", "name": "error"},
-				"timestamp": common.MapStr{"us": timestampUs},
-				"labels":    common.MapStr{"label": 101},
+		"objectAndArray": {
+			input:  map[string]interface{}{"log": log, "logs": []interface{}{second}},
+			logs:   []Log{{Message: "second", Stacktrace: m.Stacktrace{}}},
+			output: []common.MapStr{{"message": "log message"}, {"message": "second"}},
+		},
+		"disabled": {
+			input:    map[string]interface{}{"log": log, "logs": []interface{}{second}},
+			disabled: true,
+			output:   common.MapStr{"message": "log message"},
+		},
+		"invalidElement": {
+			input: map[string]interface{}{"logs": []interface{}{second, "third"}},
+			err:   "error.logs[1]: expected object, got string",
+		},
+		"invalidField": {
+			input: map[string]interface{}{"logs": []interface{}{map[string]interface{}{"message": "m", "level": 1.0}}},
+			err:   "error.logs[0].level: expected string, got number",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			transformable, err := DecodeEvent(test.input, m.Config{DecodeLogsArray: !test.disabled}, nil)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			event := transformable.(*Event)
+			assert.Equal(t, test.logs, event.Logs)
+
+			output, _ := event.Document(nil).GetValue("error.log")
+			assert.Equal(t, test.output, output)
+		})
+	}
+}
+
+func TestDecodeMaxStacktraceFrames(t *testing.T) {
+	frames := func(n int) []interface{} {
+		st := make([]interface{}, n)
+		for i := range st {
+			st[i] = map[string]interface{}{"filename": fmt.Sprintf("file%d", i), "lineno": float64(i)}
+		}
+		return st
+	}
+	input := func(exFrames, logFrames int) map[string]interface{} {
+		return map[string]interface{}{
+			"exception": map[string]interface{}{"message": "ex", "stacktrace": frames(exFrames)},
+			"log":       map[string]interface{}{"message": "log", "stacktrace": frames(logFrames)},
+		}
+	}
+	for name, test := range map[string]struct {
+		input               map[string]interface{}
+		max                 int
+		exFrames, logFrames int
+		truncated           int64
+	}{
+		"unlimited":  {input: input(10, 10), exFrames: 10, logFrames: 10},
+		"underLimit": {input: input(3, 5), max: 5, exFrames: 3, logFrames: 5},
+		"overLimit":  {input: input(10, 4), max: 5, exFrames: 5, logFrames: 4, truncated: 1},
+		"bothOver":   {input: input(10, 6), max: 5, exFrames: 5, logFrames: 5, truncated: 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			before := truncatedStacktraces.Get()
+			transformable, err := DecodeEvent(test.input, m.Config{MaxStacktraceFrames: test.max}, nil)
+			require.NoError(t, err)
+			event := transformable.(*Event)
+			require.Len(t, event.Exception.Stacktrace, test.exFrames)
+			require.Len(t, event.Log.Stacktrace, test.logFrames)
+			assert.Equal(t, "file0", event.Exception.Stacktrace[0].Filename)
+			assert.Equal(t, test.truncated, truncatedStacktraces.Get()-before)
+		})
+	}
+
+	// the grouping key is computed from the truncated stacktrace
+	truncated, err := DecodeEvent(input(10, 0), m.Config{MaxStacktraceFrames: 5}, nil)
+	require.NoError(t, err)
+	short, err := DecodeEvent(input(5, 0), m.Config{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, short.(*Event).calcGroupingKey(), truncated.(*Event).calcGroupingKey())
+}
+
+func TestDecodeStacktraceFramesOmitted(t *testing.T) {
+	frames := []interface{}{map[string]interface{}{"filename": "file0", "lineno": 1.0}}
+	omitted := 12
+	for name, test := range map[string]struct {
+		stacktrace    interface{}
+		framesOmitted *int
+		err           string
+	}{
+		"array":         {stacktrace: frames},
+		"object":        {stacktrace: map[string]interface{}{"frames": frames, "frames_omitted": 12.0}, framesOmitted: &omitted},
+		"objectNoCount": {stacktrace: map[string]interface{}{"frames": frames}},
+		"invalidCount": {
+			stacktrace: map[string]interface{}{"frames": frames, "frames_omitted": "12"},
+			err:        "exception.stacktrace.frames_omitted",
+		},
+		"invalidFrames": {
+			stacktrace: map[string]interface{}{"frames": []interface{}{"frame"}},
+			err:        "exception.stacktrace.frames",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			input := map[string]interface{}{
+				"exception": map[string]interface{}{"message": "ex", "stacktrace": test.stacktrace},
+				"log":       map[string]interface{}{"message": "log", "stacktrace": test.stacktrace},
+			}
+			transformable, err := DecodeEvent(input, m.Config{}, nil)
+			if test.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.err)
+				return
+			}
+			require.NoError(t, err)
+			event := transformable.(*Event)
+			require.Len(t, event.Exception.Stacktrace, 1)
+			require.Len(t, event.Log.Stacktrace, 1)
+			assert.Equal(t, test.framesOmitted, event.Exception.FramesOmitted)
+			assert.Equal(t, test.framesOmitted, event.Log.FramesOmitted)
+
+			fields := event.fields(&transform.Context{})
+			exception := fields["exception"].([]common.MapStr)[0]
+			log := fields["log"].(common.MapStr)
+			if test.framesOmitted == nil {
+				assert.NotContains(t, exception, "stacktrace_frames_omitted")
+				assert.NotContains(t, log, "stacktrace_frames_omitted")
+			} else {
+				assert.Equal(t, *test.framesOmitted, exception["stacktrace_frames_omitted"])
+				assert.Equal(t, *test.framesOmitted, log["stacktrace_frames_omitted"])
+			}
+		})
+	}
+}
+
+func TestDecodeErrorReasons(t *testing.T) {
+	timestamp := json.Number("1496170407154000")
+	reasons := []error{ErrMissingInput, ErrInvalidType, ErrInvalidStacktrace, ErrValidation}
+	for name, test := range map[string]struct {
+		input  interface{}
+		cfg    m.Config
+		reason error
+		msg    string
+	}{
+		"missingInput": {input: nil, reason: ErrMissingInput, msg: "Input missing for decoding Event"},
+		"invalidType":  {input: "", reason: ErrInvalidType, msg: "invalid type for error event"},
+		"invalidField": {
+			input:  map[string]interface{}{"id": 123},
+			reason: ErrInvalidType,
+			msg:    "error.id: expected string, got number",
+		},
+		"invalidContext": {
+			input:  map[string]interface{}{"context": map[string]interface{}{"tags": "a"}},
+			reason: ErrInvalidType,
+		},
+		"exceptionStacktrace": {
+			input: map[string]interface{}{
+				"exception": map[string]interface{}{"message": "msg", "stacktrace": "123"},
 			},
-			Msg: "Payload with valid Event.",
+			reason: ErrInvalidStacktrace,
 		},
-		{
-			Transformable: &Event{Timestamp: timestamp, TransactionType: &transactionType},
-			Output: common.MapStr{
-				"transaction": common.MapStr{"type": "request"},
-				"error": common.MapStr{
-					"grouping_key": "d41d8cd98f00b204e9800998ecf8427e",
-				},
-				"processor": common.MapStr{"event": "error", "name": "error"},
-				"service":   common.MapStr{"name": "myservice"},
-				"user":      common.MapStr{"id": uid},
-				"timestamp": common.MapStr{"us": timestampUs},
-				"agent":     common.MapStr{"name": "go", "version": "1.0"},
-				"labels":    common.MapStr{"label": 101},
+		"groupingKey": {
+			input:  map[string]interface{}{"grouping_key": "xyz"},
+			reason: ErrValidation,
+			msg:    "error.grouping_key: expected non-empty hex string",
+		},
+		"exceptionOrLog": {
+			input:  map[string]interface{}{},
+			cfg:    m.Config{RequireExceptionOrLog: true},
+			reason: ErrValidation,
+		},
+		"traceId": {
+			input:  map[string]interface{}{"transaction_id": "abc"},
+			cfg:    m.Config{RequireTraceId: true},
+			reason: ErrValidation,
+		},
+		"timestamp": {
+			input:  map[string]interface{}{"timestamp": timestamp},
+			cfg:    m.Config{MaxTimestampPastSkew: time.Hour},
+			reason: ErrValidation,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := DecodeEvent(test.input, test.cfg, nil)
+			require.Error(t, err)
+			for _, reason := range reasons {
+				assert.Equal(t, reason == test.reason, errors.Is(err, reason), reason.Error())
+			}
+			if test.msg != "" {
+				assert.EqualError(t, err, test.msg)
+			}
+		})
+	}
+
+	// the cause of a failure can still be inspected
+	_, err := DecodeEvent(map[string]interface{}{"id": 123}, m.Config{}, nil)
+	var fieldErr *utility.FieldError
+	require.True(t, errors.As(err, &fieldErr))
+	assert.Equal(t, "error.id", fieldErr.Path)
+
+	// errors passed in are returned as they are
+	inputErr := errors.New("input error")
+	_, err = DecodeEvent(nil, m.Config{}, inputErr)
+	assert.Equal(t, inputErr, err)
+}
+
+func TestNewEvent(t *testing.T) {
+	msg, exType := "exception message", "TypeError"
+	ts := time.Date(2019, 1, 3, 15, 17, 4, 908.596*1e6, time.FixedZone("+0100", 3600))
+	traceId, parentId, transactionId := "0123456789abcdef0123456789abcdef", "0123456789abcdef", "945254c567a5417e"
+	for name, test := range map[string]struct {
+		opts  []EventOption
+		check func(t *testing.T, e *Event)
+		err   string
+	}{
+		"exception": {
+			opts: []EventOption{WithException(Exception{Message: &msg})},
+			check: func(t *testing.T, e *Event) {
+				assert.Equal(t, &msg, e.Exception.Message)
+				assert.WithinDuration(t, time.Now(), e.Timestamp, time.Minute)
 			},
-			Msg: "Payload with valid Event.",
 		},
-		{
-			Transformable: &Event{
-				Timestamp: timestamp,
-				Log:       baseLog(),
-				Exception: &Exception{
-					Message:    &exMsg,
-					Stacktrace: m.Stacktrace{&m.StacktraceFrame{Filename: "myFile"}},
-				},
-				TransactionId:      &trId,
-				TransactionSampled: &sampledTrue,
-				User:               &metadata.User{Email: &email, IP: &userIp, UserAgent: &userAgent},
-				Labels:             &labels,
-				Page:               &m.Page{Url: &url, Referer: &referer},
-				Custom:             &custom,
+		"logWithTimestampAndId": {
+			opts: []EventOption{WithLog(Log{Message: msg}), WithTimestamp(ts), WithId("abc123")},
+			check: func(t *testing.T, e *Event) {
+				assert.Equal(t, msg, e.Log.Message)
+				assert.Equal(t, ts, e.Timestamp)
+				assert.Equal(t, "abc123", *e.Id)
+			},
+		},
+		"transaction": {
+			opts: []EventOption{
+				WithException(Exception{Type: &exType}),
+				WithTransaction(transactionId, traceId, parentId),
+				WithConfig(m.Config{TraceIdValidation: "strict"}),
+			},
+			check: func(t *testing.T, e *Event) {
+				assert.Equal(t, transactionId, *e.TransactionId)
+				assert.Equal(t, traceId, *e.TraceId)
+				assert.Equal(t, parentId, *e.ParentId)
+				assert.Equal(t, "strict", e.config.TraceIdValidation)
+			},
+		},
+		"noExceptionOrLog": {
+			opts: []EventOption{WithId("abc123")},
+			err:  "error: requires an exception or a log",
+		},
+		"emptyException": {
+			opts: []EventOption{WithException(Exception{})},
+			err:  "error.exception: requires a message or a type",
+		},
+		"invalid": {
+			opts: []EventOption{
+				WithLog(Log{Message: msg}),
+				WithTransaction(transactionId, parentId, parentId),
+				WithConfig(m.Config{TraceIdValidation: "strict"}),
+			},
+			err: "error.trace_id: expected 32 hex characters",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e, err := NewEvent(test.opts...)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				assert.Nil(t, e)
+				return
+			}
+			require.NoError(t, err)
+			test.check(t, e)
+			assert.Len(t, e.Transform(&transform.Context{}), 1)
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	id, otherId, culprit, otherCulprit, traceId := "1", "2", "handler", "other handler", "abc"
+	sampled := true
+	earlier := time.Date(2020, 1, 1, 10, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Second)
+
+	e := Event{
+		Id:        &id,
+		Timestamp: later,
+		Labels:    &m.Labels{"team": "payments"},
+		Exception: baseException().withType("type"),
+		config:    m.Config{MaxLabels: 1},
+	}
+	other := Event{
+		Id:                 &otherId,
+		Timestamp:          earlier,
+		Culprit:            &otherCulprit,
+		TraceId:            &traceId,
+		TransactionSampled: &sampled,
+		Labels:             &m.Labels{"team": "shipping", "region": "eu"},
+		Custom:             &m.Custom{"order": "42"},
+		Page:               &m.Page{},
+		Exception:          baseException().withType("other"),
+		Log:                baseLog(),
+	}
+	e.Merge(&other)
+
+	assert.Equal(t, "1", *e.Id, "present values are kept")
+	assert.Equal(t, "other handler", *e.Culprit, "missing values are filled")
+	assert.Equal(t, &traceId, e.TraceId)
+	assert.Equal(t, &sampled, e.TransactionSampled)
+	assert.Equal(t, earlier, e.Timestamp, "earlier timestamp is kept")
+	assert.Equal(t, &m.Labels{"team": "payments", "region": "eu"}, e.Labels)
+	assert.Equal(t, &m.Custom{"order": "42"}, e.Custom)
+	assert.Equal(t, other.Page, e.Page)
+	assert.Equal(t, "type", *e.Exception.Type)
+	assert.Equal(t, other.Log, e.Log)
+	assert.Nil(t, e.ParentId)
+	assert.Equal(t, m.Config{MaxLabels: 1}, e.config)
+
+	e = Event{Culprit: &culprit, Timestamp: earlier}
+	e.Merge(&Event{Culprit: &otherCulprit, Timestamp: later})
+	assert.Equal(t, earlier, e.Timestamp)
+	assert.Equal(t, "handler", *e.Culprit)
+
+	// zero timestamps are filled, but don't replace set ones
+	e = Event{}
+	e.Merge(&Event{Timestamp: later})
+	assert.Equal(t, later, e.Timestamp)
+	e.Merge(&Event{})
+	assert.Equal(t, later, e.Timestamp)
+
+	e.Merge(nil)
+	assert.Equal(t, later, e.Timestamp)
+
+	// labels and custom context pointing to nil maps are allocated
+	var labels m.Labels
+	var custom m.Custom
+	e = Event{Labels: &labels, Custom: &custom}
+	e.Merge(&Event{Labels: &m.Labels{"team": "payments"}, Custom: &m.Custom{"order": "42"}})
+	assert.Equal(t, &m.Labels{"team": "payments"}, e.Labels)
+	assert.Equal(t, &m.Custom{"order": "42"}, e.Custom)
+
+	// exception and log are copied from other
+	other = Event{Exception: baseException().withType("other"), Log: baseLog()}
+	e = Event{}
+	e.Merge(&other)
+	otherType := "changed"
+	e.Exception.Type = &otherType
+	e.Log.Message = "changed"
+	assert.Equal(t, "other", *other.Exception.Type)
+	assert.Equal(t, baseLog().Message, other.Log.Message)
+}
+
+func TestValidate(t *testing.T) {
+	trId, traceId, validKey, invalidKey := "945254c5", "0123456789abcdef0123456789abcdef", "dc9ed07b49de3c9d15", "not-a-key"
+	parentId := "0123456789abcdef"
+	past := time.Now().Add(-2 * time.Hour)
+	for name, test := range map[string]struct {
+		event Event
+		err   string
+	}{
+		"empty":            {event: Event{}},
+		"validGroupingKey": {event: Event{GroupingKey: &validKey}},
+		"invalidGroupingKey": {
+			event: Event{GroupingKey: &invalidKey},
+			err:   "error.grouping_key: expected non-empty hex string",
+		},
+		"exceptionOrLogMissing": {
+			event: Event{config: m.Config{RequireExceptionOrLog: true}},
+			err:   "error: requires an exception or a log",
+		},
+		"exceptionOrLogFromLogs": {
+			event: Event{Logs: []Log{{Message: "log"}}, config: m.Config{RequireExceptionOrLog: true}},
+		},
+		"traceIdMissing": {
+			event: Event{TransactionId: &trId, config: m.Config{RequireTraceId: true}},
+			err:   "error.trace_id: required when error.transaction_id is set",
+		},
+		"traceIdPresent": {
+			event: Event{TransactionId: &trId, TraceId: &traceId, config: m.Config{RequireTraceId: true}},
+		},
+		"timestampWithinSkew": {
+			event: Event{Timestamp: time.Now(), config: m.Config{MaxTimestampPastSkew: time.Hour}},
+		},
+		"timestampSkewed": {
+			event: Event{Timestamp: past, config: m.Config{MaxTimestampPastSkew: time.Hour}},
+			err:   "error.timestamp: " + past.Format(time.RFC3339Nano) + " is more than 1h0m0s in the past",
+		},
+		"traceIdsValid": {
+			event: Event{TraceId: &traceId, ParentId: &parentId, config: m.Config{TraceIdValidation: "strict"}},
+		},
+		"traceIdInvalid": {
+			event: Event{TraceId: &parentId, ParentId: &parentId, config: m.Config{TraceIdValidation: "strict"}},
+			err:   "error.trace_id: expected 32 hex characters",
+		},
+		"parentIdInvalid": {
+			event: Event{TraceId: &traceId, ParentId: &traceId, config: m.Config{TraceIdValidation: "strict"}},
+			err:   "error.parent_id: expected 16 hex characters",
+		},
+		"traceIdsNotValidated": {
+			event: Event{TraceId: &parentId, ParentId: &traceId},
+		},
+		"timestampSkewedClamped": {
+			// clamping happens when decoding, the event itself is invalid
+			event: Event{Timestamp: past, config: m.Config{MaxTimestampPastSkew: time.Hour, ClampTimestamps: true}},
+			err:   "error.timestamp: " + past.Format(time.RFC3339Nano) + " is more than 1h0m0s in the past",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := test.event.Validate()
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDecodeParentId(t *testing.T) {
+	flatId, nestedId := "0123456789abcdef", "fedcba9876543210"
+	for name, test := range map[string]struct {
+		input    map[string]interface{}
+		parentId *string
+	}{
+		"flat":   {input: map[string]interface{}{"parent_id": flatId}, parentId: &flatId},
+		"nested": {input: map[string]interface{}{"parent": map[string]interface{}{"id": nestedId}}, parentId: &nestedId},
+		"both": {
+			input:    map[string]interface{}{"parent_id": flatId, "parent": map[string]interface{}{"id": nestedId}},
+			parentId: &nestedId,
+		},
+		"nestedNull": {
+			input:    map[string]interface{}{"parent_id": flatId, "parent": map[string]interface{}{"id": nil}},
+			parentId: &flatId,
+		},
+		"none": {input: map[string]interface{}{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			input := map[string]interface{}{
+				"exception": map[string]interface{}{"message": "exception message"},
+				"trace_id":  "0123456789abcdef0123456789abcdef",
+			}
+			for k, v := range test.input {
+				input[k] = v
+			}
+			e, err := DecodeEvent(input, m.Config{}, nil)
+			require.NoError(t, err)
+			assert.Equal(t, test.parentId, e.(*Event).ParentId)
+		})
+	}
+
+	_, err := DecodeEvent(map[string]interface{}{
+		"exception": map[string]interface{}{"message": "exception message"},
+		"parent":    map[string]interface{}{"id": 123},
+	}, m.Config{}, nil)
+	assert.True(t, errors.Is(err, ErrInvalidType))
+}
+
+func TestDecodeTraceIdValidation(t *testing.T) {
+	validTraceId, validParentId := "0123456789abcdef0123456789ABCDEF", "0123456789abcdef"
+	for name, test := range map[string]struct {
+		traceId, parentId string
+		validTrace        bool
+		validParent       bool
+	}{
+		"valid":              {traceId: validTraceId, parentId: validParentId, validTrace: true, validParent: true},
+		"wrongTraceIdLength": {traceId: validParentId, parentId: validParentId, validParent: true},
+		"wrongParentLength":  {traceId: validTraceId, parentId: validTraceId, validTrace: true},
+		"nonHex":             {traceId: "0123456789abcdef0123456789abcdeg", parentId: "0123456789abcdeg"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			input := map[string]interface{}{
+				"exception":      map[string]interface{}{"message": "exception message"},
+				"transaction_id": "945254c567a5417e",
+				"trace_id":       test.traceId,
+				"parent_id":      test.parentId,
+			}
+
+			// ids are passed on verbatim by default
+			e, err := DecodeEvent(input, m.Config{}, nil)
+			require.NoError(t, err)
+			assert.Equal(t, test.traceId, *e.(*Event).TraceId)
+			assert.Equal(t, test.parentId, *e.(*Event).ParentId)
+
+			before := invalidIdsDropped.Get()
+			e, err = DecodeEvent(input, m.Config{TraceIdValidation: "lenient"}, nil)
+			require.NoError(t, err)
+			event := e.(*Event)
+			dropped := 0
+			if test.validTrace {
+				assert.Equal(t, test.traceId, *event.TraceId)
+			} else {
+				assert.Nil(t, event.TraceId)
+				dropped++
+			}
+			if test.validParent {
+				assert.Equal(t, test.parentId, *event.ParentId)
+			} else {
+				assert.Nil(t, event.ParentId)
+				dropped++
+			}
+			assert.Equal(t, int64(dropped), invalidIdsDropped.Get()-before)
+
+			_, err = DecodeEvent(input, m.Config{TraceIdValidation: "strict"}, nil)
+			if dropped == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, ErrValidation))
+			}
+		})
+	}
+}
+
+func TestDecodeLenientContext(t *testing.T) {
+	input := func(context map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"exception": map[string]interface{}{"message": "exception message"},
+			"context":   context,
+		}
+	}
+	request := map[string]interface{}{
+		"method": "GET",
+		"url":    map[string]interface{}{"raw": "127.0.0.1", "full": "http://127.0.0.1/"},
+	}
+	for name, test := range map[string]struct {
+		input   map[string]interface{}
+		lenient bool
+		err     string
+		check   func(*testing.T, *Event)
+	}{
+		"malformedStrict": {
+			input: input(map[string]interface{}{"request": request, "tags": "a"}),
+			err:   "error.context.tags: expected object, got string",
+		},
+		"malformedLenient": {
+			input:   input(map[string]interface{}{"request": request, "tags": "a"}),
+			lenient: true,
+			check: func(t *testing.T, e *Event) {
+				// blocks decoded before the failure are kept
+				require.NotNil(t, e.Http)
+				assert.Equal(t, "get", e.Http.Request.Method)
+				require.NotNil(t, e.Url)
+				assert.Equal(t, "http://127.0.0.1/", *e.Url.Full)
+				assert.Nil(t, e.Labels)
+				assert.Nil(t, e.Custom)
+				assert.Nil(t, e.User)
+			},
+		},
+		"invalidContextLenient": {
+			input:   map[string]interface{}{"exception": map[string]interface{}{"message": "exception message"}, "context": "a"},
+			lenient: true,
+			check: func(t *testing.T, e *Event) {
+				assert.Nil(t, e.Url)
+				assert.Nil(t, e.Labels)
+			},
+		},
+		"validLenient": {
+			input:   input(map[string]interface{}{"tags": map[string]interface{}{"a": "b"}}),
+			lenient: true,
+			check: func(t *testing.T, e *Event) {
+				assert.Equal(t, &m.Labels{"a": "b"}, e.Labels)
 			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			before := contextDecodeFailures.Get()
+			transformable, err := DecodeEvent(test.input, m.Config{LenientContextDecoding: test.lenient}, nil)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				assert.Equal(t, int64(0), contextDecodeFailures.Get()-before)
+				return
+			}
+			require.NoError(t, err)
+			event := transformable.(*Event)
+			assert.Equal(t, "exception message", *event.Exception.Message)
+			test.check(t, event)
+
+			var failures int64
+			if name != "validLenient" {
+				failures = 1
+			}
+			assert.Equal(t, failures, contextDecodeFailures.Get()-before)
+		})
+	}
+}
+
+func TestDecodeEventInto(t *testing.T) {
+	full := map[string]interface{}{
+		"id": "0123", "culprit": "culprit", "grouping_key": "abcd",
+		"timestamp":      json.Number("1496170407154000"),
+		"transaction_id": "945254c5", "trace_id": "0af76519", "parent_id": "b7ad6b71",
+		"transaction": map[string]interface{}{"sampled": true, "type": "request"},
+		"context": map[string]interface{}{
+			"tags":   map[string]interface{}{"a": "b"},
+			"custom": map[string]interface{}{"c": "d"},
+		},
+		"exception": map[string]interface{}{"message": "exception message", "type": "type"},
+		"log":       map[string]interface{}{"message": "log message"},
+		"logs":      []interface{}{map[string]interface{}{"message": "other log message"}},
+	}
+	minimal := map[string]interface{}{"log": map[string]interface{}{"message": "minimal"}}
+	cfg := m.Config{DecodeLogsArray: true}
+
+	var e Event
+	require.NoError(t, DecodeEventInto(&e, full, cfg))
+	expected, err := DecodeEvent(full, cfg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, expected, &e)
+	e.Transform(&transform.Context{})
+
+	// no state of the previous decoding is left
+	require.NoError(t, DecodeEventInto(&e, minimal, cfg))
+	expected, err = DecodeEvent(minimal, cfg, nil)
+	require.NoError(t, err)
+	assert.Equal(t, expected, &e)
+
+	err = DecodeEventInto(&e, nil, cfg)
+	assert.True(t, errors.Is(err, ErrMissingInput))
+}
+
+func TestDecodeErrorMetrics(t *testing.T) {
+	timestamp := json.Number("1496170407154000")
+	for name, test := range map[string]struct {
+		input  interface{}
+		cfg    m.Config
+		reason *monitoring.Int
+	}{
+		"missingInput": {input: nil, reason: decodeErrorsMissingInput},
+		"invalidType":  {input: "", reason: decodeErrorsInvalidType},
+		"invalidField": {input: map[string]interface{}{"id": 123}, reason: decodeErrorsInvalidType},
+		"invalidContext": {
+			input:  map[string]interface{}{"context": map[string]interface{}{"tags": "a"}},
+			reason: decodeErrorsInvalidType,
+		},
+		"exceptionStacktrace": {
+			input: map[string]interface{}{
+				"exception": map[string]interface{}{"message": "msg", "stacktrace": "123"},
+			},
+			reason: decodeErrorsStacktrace,
+		},
+		"logStacktraceFrame": {
+			input: map[string]interface{}{
+				"log": map[string]interface{}{"message": "msg", "stacktrace": []interface{}{"123"}},
+			},
+			reason: decodeErrorsStacktrace,
+		},
+		"groupingKey": {input: map[string]interface{}{"grouping_key": "xyz"}, reason: decodeErrorsValidation},
+		"traceId": {
+			input:  map[string]interface{}{"transaction_id": "abc"},
+			cfg:    m.Config{RequireTraceId: true},
+			reason: decodeErrorsValidation,
+		},
+		"timestamp": {
+			input:  map[string]interface{}{"timestamp": timestamp},
+			cfg:    m.Config{MaxTimestampPastSkew: time.Hour},
+			reason: decodeErrorsValidation,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			counters := []*monitoring.Int{decodeErrors, decodeErrorsMissingInput, decodeErrorsInvalidType,
+				decodeErrorsStacktrace, decodeErrorsValidation}
+			before := make([]int64, len(counters))
+			for i, c := range counters {
+				before[i] = c.Get()
+			}
+			_, err := DecodeEvent(test.input, test.cfg, nil)
+			require.Error(t, err)
+			for i, c := range counters {
+				var expected int64
+				if c == decodeErrors || c == test.reason {
+					expected = 1
+				}
+				assert.Equal(t, expected, c.Get()-before[i], i)
+			}
+		})
+	}
+
+	before := decodeErrors.Get()
+	_, err := DecodeEvent(nil, m.Config{}, errors.New("a"))
+	assert.Error(t, err)
+	assert.Equal(t, before, decodeErrors.Get(), "errors passed in are not counted")
+}
+
+func TestEventFields(t *testing.T) {
+	id := "45678"
+	culprit := "some trigger"
+
+	errorType := "error type"
+	codeFloat := 13.0
+	module := "error module"
+	exMsg := "exception message"
+	rootMsg := "root cause"
+	handled := false
+	attributes := common.MapStr{"k1": "val1"}
+	exception := Exception{
+		Type:       &errorType,
+		Code:       codeFloat,
+		Message:    &exMsg,
+		Module:     &module,
+		Handled:    &handled,
+		Attributes: attributes,
+		Stacktrace: []*m.StacktraceFrame{{Filename: "st file"}},
+	}
+
+	level := "level"
+	loggerName := "logger"
+	logMsg := "error log message"
+	paramMsg := "param message"
+	log := Log{
+		Level:        &level,
+		Message:      logMsg,
+		ParamMessage: &paramMsg,
+		LoggerName:   &loggerName,
+	}
+	baseExceptionHash := md5.New()
+	io.WriteString(baseExceptionHash, *baseException().Message)
+	// 706a38d554b47b8f82c6b542725c05dc
+	baseExceptionGroupingKey := hex.EncodeToString(baseExceptionHash.Sum(nil))
+
+	baseLogHash := md5.New()
+	io.WriteString(baseLogHash, baseLog().Message)
+	baseLogGroupingKey := hex.EncodeToString(baseLogHash.Sum(nil))
+	trId := "945254c5-67a5-417e-8a4e-aa29efcbfb79"
+
+	tests := []struct {
+		Event  Event
+		Output common.MapStr
+		Msg    string
+	}{
+		{
+			Event:  Event{},
+			Output: common.MapStr{},
+			Msg:    "Minimal Event",
+		},
+		{
+			Event: Event{Log: baseLog()},
+			Output: common.MapStr{
+				"log":                 common.MapStr{"message": "error log message"},
+				"grouping_key":        baseLogGroupingKey,
+				"grouping_key_source": "computed",
+				"type":                "log",
+			},
+			Msg: "Minimal Event wth log",
+		},
+		{
+			Event: Event{Exception: baseException(), Log: baseLog()},
+			Output: common.MapStr{
+				"exception":           []common.MapStr{{"message": "exception message"}},
+				"log":                 common.MapStr{"message": "error log message"},
+				"grouping_key":        baseExceptionGroupingKey,
+				"grouping_key_source": "computed",
+				"type":                "both",
+				"handled":             true,
+			},
+			Msg: "Minimal Event wth log and exception",
+		},
+		{
+			Event: Event{Exception: baseException()},
+			Output: common.MapStr{
+				"exception":           []common.MapStr{{"message": "exception message"}},
+				"grouping_key":        baseExceptionGroupingKey,
+				"grouping_key_source": "computed",
+				"type":                "exception",
+				"handled":             true,
+			},
+			Msg: "Minimal Event with exception",
+		},
+		{
+			Event: Event{Exception: baseException().withCode("13")},
+			Output: common.MapStr{
+				"exception":           []common.MapStr{{"message": "exception message", "code": "13"}},
+				"grouping_key":        baseExceptionGroupingKey,
+				"grouping_key_source": "computed",
+				"type":                "exception",
+				"handled":             true,
+			},
+			Msg: "Minimal Event with exception and string code",
+		},
+		{
+			Event: Event{Exception: baseException().withCode(13)},
+			Output: common.MapStr{
+				"exception":           []common.MapStr{{"message": "exception message", "code": "13"}},
+				"grouping_key":        baseExceptionGroupingKey,
+				"grouping_key_source": "computed",
+				"type":                "exception",
+				"handled":             true,
+			},
+			Msg: "Minimal Event wth exception and int code",
+		},
+		{
+			Event: Event{Exception: baseException().withCode(13.0)},
+			Output: common.MapStr{
+				"exception":           []common.MapStr{{"message": "exception message", "code": "13"}},
+				"grouping_key":        baseExceptionGroupingKey,
+				"grouping_key_source": "computed",
+				"type":                "exception",
+				"handled":             true,
+			},
+			Msg: "Minimal Event wth exception and float code",
+		},
+		{
+			Event: Event{Exception: baseException().withCode(json.Number("13"))},
+			Output: common.MapStr{
+				"exception":           []common.MapStr{{"message": "exception message", "code": "13"}},
+				"grouping_key":        baseExceptionGroupingKey,
+				"grouping_key_source": "computed",
+				"type":                "exception",
+				"handled":             true,
+			},
+			Msg: "Minimal Event wth exception and json.Number code",
+		},
+		{
+			Event: Event{Exception: baseException().withCode(true)},
+			Output: common.MapStr{
+				"exception":           []common.MapStr{{"message": "exception message", "code": "true"}},
+				"grouping_key":        baseExceptionGroupingKey,
+				"grouping_key_source": "computed",
+				"type":                "exception",
+				"handled":             true,
+			},
+			Msg: "Minimal Event wth exception and bool code",
+		},
+		{
+			Event: Event{Exception: baseException().withCode(map[string]interface{}{"value": json.Number("500"), "category": "http"})},
+			Output: common.MapStr{
+				"exception": []common.MapStr{{
+					"message":       "exception message",
+					"code":          "500",
+					"code_category": "http",
+				}},
+				"grouping_key":        baseExceptionGroupingKey,
+				"grouping_key_source": "computed",
+				"type":                "exception",
+				"handled":             true,
+			},
+			Msg: "Minimal Event wth exception and object code",
+		},
+		{
+			Event: Event{Exception: baseException().withCode(map[string]interface{}{"foo": "bar"})},
+			Output: common.MapStr{
+				"exception":           []common.MapStr{{"message": "exception message", "code": "map[foo:bar]"}},
+				"grouping_key":        baseExceptionGroupingKey,
+				"grouping_key_source": "computed",
+				"type":                "exception",
+				"handled":             true,
+			},
+			Msg: "Minimal Event wth exception and object code without value",
+		},
+		{
+			Event: Event{Exception: baseException().withCode([]interface{}{500})},
+			Output: common.MapStr{
+				"exception":           []common.MapStr{{"message": "exception message", "code": "[500]"}},
+				"grouping_key":        baseExceptionGroupingKey,
+				"grouping_key_source": "computed",
+				"type":                "exception",
+				"handled":             true,
+			},
+			Msg: "Minimal Event wth exception and code of other type",
+		},
+		{
+			Event: Event{Exception: &Exception{
+				Message: &exMsg,
+				Cause: []Exception{{
+					Type:  &errorType,
+					Cause: []Exception{{Message: &rootMsg}},
+				}},
+			}},
+			Output: common.MapStr{
+				"exception": []common.MapStr{
+					{"message": "exception message", "cause_count": 1},
+					{"type": "error type", "cause_count": 1},
+					{"message": "root cause"},
+				},
+				"grouping_key":        hex.EncodeToString(md5With(errorType)),
+				"grouping_key_source": "computed",
+				"type":                "exception",
+				"handled":             true,
+			},
+			Msg: "Event with chained exception",
+		},
+		{
+			Event: Event{
+				Id:            &id,
+				Timestamp:     time.Now(),
+				Culprit:       &culprit,
+				Exception:     &exception,
+				Log:           &log,
+				TransactionId: &trId,
+			},
+			Output: common.MapStr{
+				"id":      "45678",
+				"culprit": "some trigger",
+				"exception": []common.MapStr{{
+					"stacktrace": []common.MapStr{{
+						"filename":              "st file",
+						"line":                  common.MapStr{"number": 0},
+						"exclude_from_grouping": false,
+						"sourcemap": common.MapStr{
+							"error":   "Colno mandatory for sourcemapping.",
+							"updated": false,
+						},
+					}},
+					"stacktrace_frame_count":           1,
+					"stacktrace_sourcemap_applied":     false,
+					"stacktrace_sourcemap_error_count": 1,
+					"code":                             "13",
+					"message":                          "exception message",
+					"module":                           "error module",
+					"attributes":                       common.MapStr{"k1": "val1"},
+					"type":                             "error type",
+					"handled":                          false,
+				}},
+				"log": common.MapStr{
+					"message":       "error log message",
+					"param_message": "param message",
+					"logger_name":   "logger",
+					"level":         "level",
+				},
+				"grouping_key":        "f14978b5c89fe664efd49ab0f838952a",
+				"grouping_key_source": "computed",
+				"type":                "both",
+				"handled":             false,
+			},
+			Msg: "Event with frames",
+		},
+	}
+
+	s := "myservice"
+	tctx := &transform.Context{
+		Config: transform.Config{SmapMapper: &sourcemap.SmapMapper{}},
+		Metadata: metadata.Metadata{
+			Service: &metadata.Service{Name: &s},
+		},
+	}
+
+	for idx, test := range tests {
+		output := test.Event.Transform(tctx)
+		require.Len(t, output, 1)
+		fields := output[0].Fields["error"]
+		assert.Equal(t, test.Output, fields, fmt.Sprintf("Failed at idx %v; %s", idx, test.Msg))
+	}
+}
+
+func TestErrorType(t *testing.T) {
+	for name, test := range map[string]struct {
+		event    Event
+		expected interface{}
+	}{
+		"none":          {event: Event{}, expected: nil},
+		"exception":     {event: Event{Exception: baseException()}, expected: "exception"},
+		"log":           {event: Event{Log: baseLog()}, expected: "log"},
+		"logsArray":     {event: Event{Logs: []Log{*baseLog()}}, expected: "log"},
+		"both":          {event: Event{Exception: baseException(), Log: baseLog()}, expected: "both"},
+		"bothLogsArray": {event: Event{Exception: baseException(), Logs: []Log{*baseLog()}}, expected: "both"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fields := test.event.Document(nil)["error"].(common.MapStr)
+			assert.Equal(t, test.expected, fields["type"])
+		})
+	}
+}
+
+func TestGroupingName(t *testing.T) {
+	components := []string{"service.environment", "error.grouping_key"}
+	document := func(env string, cfg m.Config) common.MapStr {
+		e := Event{Exception: baseException().withType("type"), config: cfg}
+		tctx := &transform.Context{}
+		if env != "" {
+			name := "service"
+			tctx.Metadata.Service = &metadata.Service{Name: &name, Environment: &env}
+		}
+		return e.Document(tctx)
+	}
+	groupingName := func(doc common.MapStr) interface{} {
+		name, _ := doc.GetValue("error.grouping_name")
+		return name
+	}
+	cfg := m.Config{GroupingNameComponents: components}
+
+	assert.Nil(t, groupingName(document("production", m.Config{})))
+
+	production, staging := document("production", cfg), document("staging", cfg)
+	key := production["error"].(common.MapStr)["grouping_key"]
+	assert.Equal(t, key, staging["error"].(common.MapStr)["grouping_key"])
+	assert.Equal(t, "production:"+key.(string), groupingName(production))
+	assert.Equal(t, "staging:"+key.(string), groupingName(staging))
+
+	// missing components contribute empty values
+	assert.Equal(t, ":"+key.(string), groupingName(document("", cfg)))
+	assert.Nil(t, groupingName(document("", m.Config{GroupingNameComponents: []string{"service.environment"}})))
+}
+
+func TestEvents(t *testing.T) {
+
+	timestamp, _ := time.Parse(time.RFC3339, "2019-01-03T15:17:04.908596+01:00")
+	timestampUs := timestamp.UnixNano() / 1000
+	serviceName, agentName, version := "myservice", "go", "1.0"
+	service := metadata.Service{
+		Name: &serviceName, Agent: metadata.Agent{Name: &agentName, Version: &version},
+	}
+	exMsg := "exception message"
+	trId := "945254c5-67a5-417e-8a4e-aa29efcbfb79"
+	sampledTrue, sampledFalse := true, false
+	transactionType := "request"
+
+	email, userIp, userAgent := "m@m.com", "127.0.0.1", "js-1.0"
+	uid := "1234567889"
+	url, referer := "https://localhost", "http://localhost"
+	labels := m.Labels(common.MapStr{"key": true})
+	custom := m.Custom(common.MapStr{"foo": "bar"})
+	metadataLabels := common.MapStr{"label": 101}
+	tests := []struct {
+		Transformable transform.Transformable
+		Output        common.MapStr
+		Msg           string
+	}{
+		{
+			Transformable: &Event{Timestamp: timestamp},
+			Output: common.MapStr{
+				"agent":     common.MapStr{"name": "go", "version": "1.0"},
+				"service":   common.MapStr{"name": "myservice"},
+				"error":     common.MapStr{},
+				"user":      common.MapStr{"id": uid},
+				"processor": common.MapStr{"event": "error", "name": "error"},
+				"timestamp": common.MapStr{"us": timestampUs},
+				"event":     common.MapStr{"ingested": timestamp},
+				"labels":    common.MapStr{"label": 101},
+			},
+			Msg: "Payload with valid Event.",
+		},
+		{
+			Transformable: &Event{Timestamp: timestamp, TransactionSampled: &sampledFalse},
+			Output: common.MapStr{
+				"transaction": common.MapStr{"sampled": false},
+				"agent":       common.MapStr{"name": "go", "version": "1.0"},
+				"service":     common.MapStr{"name": "myservice"},
+				"error":       common.MapStr{},
+				"user":        common.MapStr{"id": uid},
+				"processor":   common.MapStr{"event": "error", "name": "error"},
+				"timestamp":   common.MapStr{"us": timestampUs},
+				"event":       common.MapStr{"ingested": timestamp},
+				"labels":      common.MapStr{"label": 101},
+			},
+			Msg: "Payload with valid Event.",
+		},
+		{
+			Transformable: &Event{Timestamp: timestamp, TransactionType: &transactionType},
+			Output: common.MapStr{
+				"transaction": common.MapStr{"type": "request"},
+				"error":       common.MapStr{},
+				"processor":   common.MapStr{"event": "error", "name": "error"},
+				"service":     common.MapStr{"name": "myservice"},
+				"user":        common.MapStr{"id": uid},
+				"timestamp":   common.MapStr{"us": timestampUs},
+				"event":       common.MapStr{"ingested": timestamp},
+				"agent":       common.MapStr{"name": "go", "version": "1.0"},
+				"labels":      common.MapStr{"label": 101},
+			},
+			Msg: "Payload with valid Event.",
+		},
+		{
+			Transformable: &Event{
+				Timestamp: timestamp,
+				Log:       baseLog(),
+				Exception: &Exception{
+					Message:    &exMsg,
+					Stacktrace: m.Stacktrace{&m.StacktraceFrame{Filename: "myFile"}},
+				},
+				TransactionId:      &trId,
+				TransactionSampled: &sampledTrue,
+				User:               &metadata.User{Email: &email, IP: &userIp, UserAgent: &userAgent},
+				Labels:             &labels,
+				Page:               &m.Page{Url: &url, Referer: &referer},
+				Custom:             &custom,
+			},
+
+			Output: common.MapStr{
+				"labels":     common.MapStr{"key": true, "label": 101},
+				"service":    common.MapStr{"name": "myservice"},
+				"agent":      common.MapStr{"name": "go", "version": "1.0"},
+				"user":       common.MapStr{"email": email},
+				"client":     common.MapStr{"ip": userIp},
+				"user_agent": common.MapStr{"original": userAgent},
+				"error": common.MapStr{
+					"custom": common.MapStr{
+						"foo": "bar",
+					},
+					"grouping_key":        "8859850ca8ac6b0cb59003585912a8fd",
+					"grouping_key_source": "computed",
+					"handled":             true,
+					"log":                 common.MapStr{"message": "error log message"},
+					"type":                "both",
+					"exception": []common.MapStr{{
+						"message": "exception message",
+						"stacktrace": []common.MapStr{{
+							"exclude_from_grouping": false,
+							"filename":              "myFile",
+							"line":                  common.MapStr{"number": 0},
+							"sourcemap": common.MapStr{
+								"error":   "Colno mandatory for sourcemapping.",
+								"updated": false,
+							},
+						}},
+						"stacktrace_frame_count":           1,
+						"stacktrace_sourcemap_applied":     false,
+						"stacktrace_sourcemap_error_count": 1,
+					}},
+					"page": common.MapStr{"url": url, "referer": referer},
+				},
+				"processor":   common.MapStr{"event": "error", "name": "error"},
+				"transaction": common.MapStr{"id": "945254c5-67a5-417e-8a4e-aa29efcbfb79", "sampled": true},
+				"timestamp":   common.MapStr{"us": timestampUs},
+				"event":       common.MapStr{"ingested": timestamp},
+			},
+			Msg: "Payload with Event with Context.",
+		},
+	}
+
+	me := metadata.NewMetadata(&service, nil, nil, &metadata.User{Id: &uid}, metadataLabels)
+	tctx := &transform.Context{
+		Metadata:    *me,
+		Config:      transform.Config{SmapMapper: &sourcemap.SmapMapper{}},
+		RequestTime: timestamp,
+	}
+
+	for idx, test := range tests {
+		outputEvents := test.Transformable.Transform(tctx)
+		require.Len(t, outputEvents, 1)
+		outputEvent := outputEvents[0]
+		assert.Equal(t, test.Output, outputEvent.Fields, fmt.Sprintf("Failed at idx %v; %s", idx, test.Msg))
+		assert.Equal(t, timestamp, outputEvent.Timestamp, fmt.Sprintf("Bad timestamp at idx %v; %s", idx, test.Msg))
+	}
+}
+
+func TestDocument(t *testing.T) {
+	requestTime := time.Now()
+	tctx := &transform.Context{RequestTime: requestTime}
+	event := func() *Event {
+		return &Event{Exception: baseException().withType("type"), Log: baseLog()}
+	}
+	transformed := event().Transform(tctx)
+	require.Len(t, transformed, 1)
+	assert.Equal(t, transformed[0].Fields, event().Document(tctx))
+
+	doc := event().Document(nil)
+	assert.Equal(t, common.MapStr{"event": "error", "name": "error"}, doc["processor"])
+	assert.NotContains(t, doc, "timestamp")
+	assert.Contains(t, doc, "error")
+}
+
+func TestEventIngested(t *testing.T) {
+	timestamp := time.Date(2019, 1, 3, 15, 17, 4, 908596000, time.UTC)
+	requestTime := timestamp.Add(1500 * time.Millisecond)
+	tctx := &transform.Context{RequestTime: requestTime}
+
+	fields := (&Event{Timestamp: timestamp}).Transform(tctx)[0].Fields
+	assert.Equal(t, common.MapStr{"us": timestamp.UnixNano() / 1000}, fields["timestamp"])
+	assert.Equal(t, common.MapStr{"ingested": requestTime}, fields["event"])
+
+	fields = (&Event{Timestamp: timestamp, config: m.Config{OmitEventIngested: true}}).Transform(tctx)[0].Fields
+	assert.Equal(t, common.MapStr{"us": timestamp.UnixNano() / 1000}, fields["timestamp"])
+	assert.NotContains(t, fields, "event")
+
+	// without a request time there is nothing to record
+	fields = (&Event{Timestamp: timestamp}).Transform(&transform.Context{})[0].Fields
+	assert.NotContains(t, fields, "event")
+}
+
+func TestTimestampResolution(t *testing.T) {
+	timestamp := time.Date(2019, 1, 3, 15, 17, 4, 908596123, time.UTC)
+	requestTime := time.Date(2019, 1, 3, 15, 17, 5, 123456789, time.UTC)
+	tctx := &transform.Context{RequestTime: requestTime}
+
+	for _, test := range []struct {
+		resolution          string
+		timestamp, ingested time.Time
+	}{
+		{
+			resolution: "",
+			timestamp:  timestamp,
+			ingested:   requestTime,
+		},
+		{
+			resolution: "microsecond",
+			timestamp:  time.Date(2019, 1, 3, 15, 17, 4, 908596000, time.UTC),
+			ingested:   time.Date(2019, 1, 3, 15, 17, 5, 123456000, time.UTC),
+		},
+		{
+			resolution: "millisecond",
+			timestamp:  time.Date(2019, 1, 3, 15, 17, 4, 908000000, time.UTC),
+			ingested:   time.Date(2019, 1, 3, 15, 17, 5, 123000000, time.UTC),
+		},
+		{
+			resolution: "second",
+			timestamp:  time.Date(2019, 1, 3, 15, 17, 4, 0, time.UTC),
+			ingested:   time.Date(2019, 1, 3, 15, 17, 5, 0, time.UTC),
+		},
+	} {
+		event := &Event{Timestamp: timestamp, config: m.Config{TimestampResolution: test.resolution}}
+		output := event.Transform(tctx)
+		require.Len(t, output, 1, test.resolution)
+		assert.Equal(t, test.timestamp, output[0].Timestamp, test.resolution)
+		assert.Equal(t, common.MapStr{"us": test.timestamp.UnixNano() / 1000}, output[0].Fields["timestamp"], test.resolution)
+		assert.Equal(t, common.MapStr{"ingested": test.ingested}, output[0].Fields["event"], test.resolution)
+	}
+}
+
+func TestLabelsDeterministicOutput(t *testing.T) {
+	labels := m.Labels{"zone": "b", "app": "shop", "tier": 1, "canary": true}
+	tctx := &transform.Context{Metadata: metadata.Metadata{Labels: common.MapStr{"zone": "a", "region": "eu", "build": "42"}}}
+	encodeLabels := func() string {
+		e := Event{Labels: &labels}
+		b, err := json.Marshal(e.Transform(tctx)[0].Fields["labels"])
+		require.NoError(t, err)
+		return string(b)
+	}
+	expected := `{"app":"shop","build":"42","canary":true,"region":"eu","tier":1,"zone":"b"}`
+	for i := 0; i < 20; i++ {
+		assert.Equal(t, expected, encodeLabels())
+	}
+}
+
+func TestEventServiceMergesWithMetadata(t *testing.T) {
+	metadataName, metadataVersion, metadataEnv, agentName := "checkout", "1.0", "production", "java"
+	tctx := &transform.Context{Metadata: metadata.Metadata{Service: &metadata.Service{
+		Name:        &metadataName,
+		Version:     &metadataVersion,
+		Environment: &metadataEnv,
+		Agent:       metadata.Agent{Name: &agentName},
+	}}}
+	for name, test := range map[string]struct {
+		service  map[string]interface{}
+		expected common.MapStr
+		agent    common.MapStr
+	}{
+		"noService": {
+			expected: common.MapStr{"name": "checkout", "version": "1.0", "environment": "production"},
+			agent:    common.MapStr{"name": "java"},
+		},
+		"versionOnly": {
+			service:  map[string]interface{}{"version": "2.0"},
+			expected: common.MapStr{"name": "checkout", "version": "2.0", "environment": "production"},
+			agent:    common.MapStr{"name": "java"},
+		},
+		"nameOnly": {
+			service:  map[string]interface{}{"name": "payments"},
+			expected: common.MapStr{"name": "payments", "version": "1.0", "environment": "production"},
+			agent:    common.MapStr{"name": "java"},
+		},
+		"nested": {
+			service: map[string]interface{}{
+				"version":  "2.0",
+				"agent":    map[string]interface{}{"version": "1.5.0"},
+				"language": map[string]interface{}{"name": "kotlin"},
+			},
+			expected: common.MapStr{"name": "checkout", "version": "2.0", "environment": "production", "language": common.MapStr{"name": "kotlin"}},
+			agent:    common.MapStr{"name": "java", "version": "1.5.0"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			input := map[string]interface{}{"exception": map[string]interface{}{"message": "exception message"}}
+			if test.service != nil {
+				input["context"] = map[string]interface{}{"service": test.service}
+			}
+			transformable, err := DecodeEvent(input, m.Config{GroupingKeyIncludeService: true}, nil)
+			require.NoError(t, err)
+			fields := transformable.Transform(tctx)[0].Fields
+			assert.Equal(t, test.expected, fields["service"])
+			assert.Equal(t, test.agent, fields["agent"])
+
+			// the merged service name scopes the grouping key
+			name := test.expected["name"].(string)
+			e := transformable.(*Event)
+			assert.Equal(t, e.calcServiceGroupingKey(&name), fields["error"].(common.MapStr)["grouping_key"])
+		})
+	}
+	// metadata is left untouched
+	assert.Equal(t, "1.0", *tctx.Metadata.Service.Version)
+}
+
+func TestMaxLabels(t *testing.T) {
+	labels := m.Labels{"d": "4", "b": "2", "e": "5"}
+	metadataLabels := common.MapStr{"a": "1", "c": "3", "e": "meta"}
+	for name, test := range map[string]struct {
+		max      int
+		expected common.MapStr
+		dropped  int64
+	}{
+		"noLimit":    {expected: common.MapStr{"a": "1", "b": "2", "c": "3", "d": "4", "e": "5"}},
+		"underLimit": {max: 6, expected: common.MapStr{"a": "1", "b": "2", "c": "3", "d": "4", "e": "5"}},
+		"atLimit":    {max: 5, expected: common.MapStr{"a": "1", "b": "2", "c": "3", "d": "4", "e": "5"}},
+		"overLimit":  {max: 3, expected: common.MapStr{"a": "1", "b": "2", "c": "3"}, dropped: 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			before := labelsDropped.Get()
+			tctx := &transform.Context{Metadata: metadata.Metadata{Labels: metadataLabels}}
+			e := Event{Labels: &labels, Log: baseLog(), config: m.Config{MaxLabels: test.max}}
+			fields := e.Transform(tctx)[0].Fields
+			assert.Equal(t, test.expected, fields["labels"])
+			assert.Equal(t, test.dropped, labelsDropped.Get()-before)
+			// metadata labels are left untouched
+			assert.Len(t, metadataLabels, 3)
+		})
+	}
+}
+
+func TestPromoteLabels(t *testing.T) {
+	labels := m.Labels{"team": "checkout", "env": "prod", "service_name": "shop"}
+	serviceName := "api"
+	tctx := &transform.Context{Metadata: metadata.Metadata{
+		Labels:  common.MapStr{"region": "eu"},
+		Service: &metadata.Service{Name: &serviceName},
+	}}
+	document := func(promote map[string]string) common.MapStr {
+		e := Event{Labels: &labels, Log: baseLog(), config: m.Config{PromoteLabels: promote}}
+		return e.Transform(tctx)[0].Fields
+	}
+
+	doc := document(nil)
+	assert.NotContains(t, doc, "team")
+
+	doc = document(map[string]string{"team": "team", "region": "cloud.region", "missing": "missing"})
+	assert.Equal(t, "checkout", doc["team"])
+	region, err := doc.GetValue("cloud.region")
+	require.NoError(t, err)
+	assert.Equal(t, "eu", region)
+	assert.NotContains(t, doc, "missing")
+	// labels are kept
+	assert.Equal(t, "checkout", doc["labels"].(common.MapStr)["team"])
+
+	// existing fields are not overwritten
+	doc = document(map[string]string{"service_name": "service.name", "env": "error.log.message", "team": "processor"})
+	name, err := doc.GetValue("service.name")
+	require.NoError(t, err)
+	assert.Equal(t, serviceName, name)
+	message, err := doc.GetValue("error.log.message")
+	require.NoError(t, err)
+	assert.Equal(t, baseLog().Message, message)
+	assert.Equal(t, common.MapStr{"name": "error", "event": "error"}, doc["processor"])
+
+	// the first label mapped to a field wins
+	doc = document(map[string]string{"team": "owner", "env": "owner"})
+	assert.Equal(t, "prod", doc["owner"])
+}
+
+func TestContextFieldsFilter(t *testing.T) {
+	name, ua, pageUrl, query, full := "jane", "go-client", "http://localhost/page", "q=secret", "http://localhost/?q=secret"
+	statusCode := 500
+	event := func(cfg m.Config) *Event {
+		return &Event{
+			User:   &metadata.User{Name: &name, UserAgent: &ua},
+			Http:   &m.Http{Response: &m.Resp{StatusCode: &statusCode}},
+			Url:    &m.Url{Full: &full, Query: &query},
+			Page:   &m.Page{Url: &pageUrl},
+			Custom: &m.Custom{"key": "value"},
+			Log:    baseLog(),
+			config: cfg,
+		}
+	}
+	present := func(doc common.MapStr, path string) bool {
+		ok, _ := doc.HasKey(path)
+		return ok
+	}
+	all := []string{"user", "user_agent", "http", "url", "url.query", "error.page", "error.custom"}
+
+	doc := event(m.Config{}).Document(nil)
+	for _, path := range all {
+		assert.True(t, present(doc, path), path)
+	}
+
+	for name, test := range map[string]struct {
+		cfg     m.Config
+		dropped []string
+	}{
+		"excludeUrlAndPage": {
+			cfg:     m.Config{ContextFieldsExclude: []string{"url", "error.page"}},
+			dropped: []string{"url", "url.query", "error.page"},
+		},
+		"excludeQuery": {
+			cfg:     m.Config{ContextFieldsExclude: []string{"url.query"}},
+			dropped: []string{"url.query"},
+		},
+		"include": {
+			cfg:     m.Config{ContextFieldsInclude: []string{"user", "user_agent", "http", "error.custom"}},
+			dropped: []string{"url", "url.query", "error.page"},
+		},
+		"includeAndExclude": {
+			cfg: m.Config{
+				ContextFieldsInclude: []string{"user", "user_agent", "http", "url", "error.custom"},
+				ContextFieldsExclude: []string{"url.query"},
+			},
+			dropped: []string{"url.query", "error.page"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			doc := event(test.cfg).Document(nil)
+			for _, path := range all {
+				assert.Equal(t, !containsString(test.dropped, path), present(doc, path), path)
+			}
+			// fields other than context are unaffected
+			for _, path := range []string{"error.log.message", "error.grouping_key", "processor.event"} {
+				assert.True(t, present(doc, path), path)
+			}
+		})
+	}
+}
+
+func TestErrorEventType(t *testing.T) {
+	for _, test := range []struct {
+		cfg       m.Config
+		eventType string
+	}{
+		{cfg: m.Config{}, eventType: "error"},
+		{cfg: m.Config{ErrorEventType: "tenant-error"}, eventType: "tenant-error"},
+	} {
+		e := Event{config: test.cfg}
+		fields := e.Transform(&transform.Context{})[0].Fields
+		assert.Equal(t, common.MapStr{"name": "error", "event": test.eventType}, fields["processor"])
+	}
+}
+
+func TestSynthesizeId(t *testing.T) {
+	id := "8ac90e6b-8bb5-4e09-8c65-29ed2a3fdaf0"
+	timestamp := time.Date(2019, 1, 3, 15, 17, 4, 908.596*1e6, time.FixedZone("+0100", 3600))
+	tctx := &transform.Context{}
+	errorId := func(e *Event) interface{} {
+		id, _ := e.Transform(tctx)[0].Fields.GetValue("error.id")
+		return id
+	}
+	event := func(mode string, msg string) *Event {
+		return &Event{Timestamp: timestamp, Log: &Log{Message: msg}, config: m.Config{SynthesizeId: mode}}
+	}
+
+	t.Run("supplied", func(t *testing.T) {
+		for _, mode := range []string{"", "deterministic", "random"} {
+			e := event(mode, "log message")
+			e.Id = &id
+			assert.Equal(t, id, errorId(e), mode)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Nil(t, errorId(event("", "log message")))
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		synthesized := errorId(event("deterministic", "log message"))
+		require.IsType(t, "", synthesized)
+		parsed, err := uuid.FromString(synthesized.(string))
+		require.NoError(t, err)
+		assert.Equal(t, byte(uuid.V5), parsed.Version())
+
+		assert.Equal(t, synthesized, errorId(event("deterministic", "log message")))
+		assert.NotEqual(t, synthesized, errorId(event("deterministic", "other message")))
+		later := event("deterministic", "log message")
+		later.Timestamp = timestamp.Add(time.Millisecond)
+		assert.NotEqual(t, synthesized, errorId(later))
+	})
+
+	t.Run("random", func(t *testing.T) {
+		synthesized := errorId(event("random", "log message"))
+		require.IsType(t, "", synthesized)
+		parsed, err := uuid.FromString(synthesized.(string))
+		require.NoError(t, err)
+		assert.Equal(t, byte(uuid.V4), parsed.Version())
+
+		assert.NotEqual(t, synthesized, errorId(event("random", "log message")))
+	})
+}
+
+func TestRedaction(t *testing.T) {
+	rules := []m.RedactionRule{
+		{Pattern: regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.]+`), Replacement: "[email]"},
+	}
+	event := func(cfg m.Config) *Event {
+		exMsg := "no account for jane.doe@example.com"
+		paramMsg := "login failed for %s (admin@example.org)"
+		function := "notify"
+		return &Event{
+			Exception: &Exception{
+				Message:    &exMsg,
+				Stacktrace: m.Stacktrace{&m.StacktraceFrame{Filename: "/home/jane@example.com/app.js", Function: &function}},
+			},
+			Log: &Log{
+				Message:      "login failed for jane.doe@example.com (admin@example.org)",
+				ParamMessage: &paramMsg,
+			},
+			config: cfg,
+		}
+	}
+	tctx := &transform.Context{}
+
+	raw := event(m.Config{EmitLogMessageTemplate: true}).Transform(tctx)[0].Fields
+	redacted := event(m.Config{EmitLogMessageTemplate: true, RedactionRules: rules}).Transform(tctx)[0].Fields
+
+	for key, expected := range map[string]string{
+		"error.culprit":              "/home/[email]/app.js in notify",
+		"error.log.message":          "login failed for [email] ([email])",
+		"error.log.param_message":    "login failed for %s ([email])",
+		"error.log.message_template": "login failed for %s ([email])",
+	} {
+		val, err := redacted.GetValue(key)
+		require.NoError(t, err, key)
+		assert.Equal(t, expected, val, key)
+	}
+	exceptions, err := redacted.GetValue("error.exception")
+	require.NoError(t, err)
+	assert.Equal(t, "no account for [email]", exceptions.([]common.MapStr)[0]["message"])
+
+	rawCulprit, _ := raw.GetValue("error.culprit")
+	assert.Equal(t, "/home/jane@example.com/app.js in notify", rawCulprit)
+
+	// grouping keys are computed from the unredacted messages
+	rawKey, _ := raw.GetValue("error.grouping_key")
+	redactedKey, _ := redacted.GetValue("error.grouping_key")
+	assert.Equal(t, rawKey, redactedKey)
+	other := event(m.Config{RedactionRules: rules})
+	otherMsg := "login failed for %s (root@example.org)"
+	other.Log.ParamMessage = &otherMsg
+	otherKey, _ := other.Transform(tctx)[0].Fields.GetValue("error.grouping_key")
+	assert.NotEqual(t, redactedKey, otherKey)
+}
+
+func TestEmptyExceptionMessage(t *testing.T) {
+	exType := "ValueError"
+	omit := m.Config{OmitEmptyExceptionMessage: true}
+	substitute := m.Config{SubstituteEmptyExceptionMessage: true}
+	both := m.Config{OmitEmptyExceptionMessage: true, SubstituteEmptyExceptionMessage: true}
+	for name, test := range map[string]struct {
+		message  string
+		exType   *string
+		cfg      m.Config
+		expected interface{}
+	}{
+		"emptyDefault":          {message: "", exType: &exType, expected: ""},
+		"whitespaceDefault":     {message: " \t", exType: &exType, expected: " \t"},
+		"normalDefault":         {message: "msg", exType: &exType, expected: "msg"},
+		"emptyOmit":             {message: "", exType: &exType, cfg: omit},
+		"whitespaceOmit":        {message: " \n", exType: &exType, cfg: omit},
+		"normalOmit":            {message: " msg ", exType: &exType, cfg: omit, expected: " msg "},
+		"emptySubstitute":       {message: "", exType: &exType, cfg: substitute, expected: exType},
+		"whitespaceSubstitute":  {message: "  ", exType: &exType, cfg: substitute, expected: exType},
+		"normalSubstitute":      {message: "msg", exType: &exType, cfg: substitute, expected: "msg"},
+		"emptySubstituteNoType": {message: "", cfg: substitute, expected: ""},
+		"emptyBoth":             {message: "", exType: &exType, cfg: both, expected: exType},
+		"emptyBothNoType":       {message: "", cfg: both},
+	} {
+		t.Run(name, func(t *testing.T) {
+			msg := test.message
+			ex := Exception{Message: &msg, Type: test.exType}
+			fields := ex.fields(&transform.Context{}, test.cfg)
+			assert.Equal(t, test.expected, fields["message"])
+		})
+	}
+}
+
+func TestPromoteExceptionAttributes(t *testing.T) {
+	attributes := func() common.MapStr {
+		return common.MapStr{
+			"threadName": "main",
+			"tid":        json.Number("17"),
+			"pid":        4711.0,
+			"pname":      []interface{}{"invalid"},
+			"other":      "value",
+		}
+	}
+	cfg := m.Config{PromoteExceptionAttributes: map[string]string{
+		"threadName": "thread_name",
+		"tid":        "thread_id",
+		"pid":        "process_id",
+		"pname":      "process_name",
+		"other":      "unknown_field",
+	}}
+
+	ex := &Exception{Message: new(string), Attributes: attributes()}
+	fields := ex.fields(&transform.Context{}, cfg)
+	assert.Equal(t, "main", fields["thread_name"])
+	assert.Equal(t, "17", fields["thread_id"])
+	assert.Equal(t, int64(4711), fields["process_id"])
+	assert.NotContains(t, fields, "process_name")
+	assert.NotContains(t, fields, "unknown_field")
+	// unrecognized and unconvertible attributes are left in the attributes
+	assert.Equal(t, common.MapStr{"pname": []interface{}{"invalid"}, "other": "value"}, fields["attributes"])
+	// the exception's attributes are not changed
+	assert.Equal(t, attributes(), ex.Attributes)
+
+	// attributes are omitted if all of them are promoted
+	ex = &Exception{Message: new(string), Attributes: common.MapStr{"threadName": "main"}}
+	fields = ex.fields(&transform.Context{}, cfg)
+	assert.Equal(t, "main", fields["thread_name"])
+	assert.NotContains(t, fields, "attributes")
+
+	// attributes are passed through by default
+	ex = &Exception{Message: new(string), Attributes: attributes()}
+	fields = ex.fields(&transform.Context{}, m.Config{})
+	assert.Len(t, fields["attributes"], 5)
+	assert.Equal(t, "main", fields["attributes"].(common.MapStr)["threadName"])
+	assert.NotContains(t, fields, "thread_name")
+}
+
+func TestExceptionCauseCount(t *testing.T) {
+	cause := func() map[string]interface{} { return map[string]interface{}{"message": "cause"} }
+	for name, test := range map[string]struct {
+		causes   []interface{}
+		cfg      m.Config
+		expected interface{}
+	}{
+		"none":            {},
+		"empty":           {causes: []interface{}{}},
+		"one":             {causes: []interface{}{cause()}, expected: 1},
+		"multiple":        {causes: []interface{}{cause(), cause(), cause()}, expected: 3},
+		"exceedsMaxDepth": {causes: []interface{}{cause(), cause()}, cfg: m.Config{MaxExceptionCauseDepth: 1}, expected: 2},
+	} {
+		t.Run(name, func(t *testing.T) {
+			exception := map[string]interface{}{"message": "exception message"}
+			if test.causes != nil {
+				exception["cause"] = test.causes
+			}
+			transformable, err := DecodeEvent(map[string]interface{}{"exception": exception}, test.cfg, nil)
+			require.NoError(t, err)
+			fields := transformable.(*Event).fields(&transform.Context{})
+			exceptions := fields["exception"].([]common.MapStr)
+			assert.Equal(t, test.expected, exceptions[0]["cause_count"])
+			for _, cause := range exceptions[1:] {
+				assert.NotContains(t, cause, "cause_count")
+			}
+		})
+	}
+}
+
+func TestSplitExceptionChain(t *testing.T) {
+	chain := func(depth int) *Exception {
+		var ex *Exception
+		for i := depth - 1; i >= 0; i-- {
+			current := baseException().withType(fmt.Sprintf("Error%d", i))
+			if ex != nil {
+				current.Cause = []Exception{*ex}
+			}
+			ex = current
+		}
+		return ex
+	}
+	id := "45678"
+	tctx := &transform.Context{RequestTime: time.Now()}
+
+	for _, depth := range []int{1, 2, 5} {
+		event := &Event{Id: &id, Exception: chain(depth), Log: baseLog(), config: m.Config{SplitExceptionChain: true}}
+		events := event.Transform(tctx)
+		require.Len(t, events, depth)
+
+		groupingKey := events[0].Fields["error"].(common.MapStr)["grouping_key"]
+		require.NotNil(t, groupingKey)
+		for idx, e := range events {
+			errorFields := e.Fields["error"].(common.MapStr)
+			assert.Equal(t, id, errorFields["id"])
+			assert.Equal(t, groupingKey, errorFields["grouping_key"])
+			assert.Equal(t, common.MapStr{"message": "error log message"}, errorFields["log"])
+			exceptions := errorFields["exception"].([]common.MapStr)
+			require.Len(t, exceptions, 1)
+			assert.Equal(t, idx, exceptions[0]["chain_position"])
+			assert.Equal(t, fmt.Sprintf("Error%d", idx), exceptions[0]["type"])
+			assert.Equal(t, event.Timestamp, e.Timestamp)
+		}
+	}
+
+	// the chain is held by a single document by default
+	events := (&Event{Id: &id, Exception: chain(5)}).Transform(tctx)
+	require.Len(t, events, 1)
+	exceptions := events[0].Fields["error"].(common.MapStr)["exception"].([]common.MapStr)
+	require.Len(t, exceptions, 5)
+	assert.NotContains(t, exceptions[0], "chain_position")
+
+	// errors without exception are not split
+	events = (&Event{Id: &id, Log: baseLog(), config: m.Config{SplitExceptionChain: true}}).Transform(tctx)
+	require.Len(t, events, 1)
+	assert.NotContains(t, events[0].Fields["error"], "exception")
+}
+
+func TestExceptionTypeHierarchy(t *testing.T) {
+	exception := func(types ...string) *Exception {
+		var ex *Exception
+		for i := len(types) - 1; i >= 0; i-- {
+			current := baseException()
+			if types[i] != "" {
+				current.withType(types[i])
+			}
+			if ex != nil {
+				current.Cause = []Exception{*ex}
+			}
+			ex = current
+		}
+		return ex
+	}
+	cfg := m.Config{EmitExceptionTypeHierarchy: true}
+	for name, test := range map[string]struct {
+		exception *Exception
+		cfg       m.Config
+		expected  interface{}
+	}{
+		"single":      {exception: exception("IOError"), cfg: cfg, expected: []string{"IOError"}},
+		"chain":       {exception: exception("ServiceError", "DbError", "IOError"), cfg: cfg, expected: []string{"ServiceError", "DbError", "IOError"}},
+		"duplicates":  {exception: exception("WrappedError", "IOError", "WrappedError", "IOError"), cfg: cfg, expected: []string{"WrappedError", "IOError"}},
+		"untyped":     {exception: exception("", "IOError", ""), cfg: cfg, expected: []string{"IOError"}},
+		"noTypes":     {exception: exception(""), cfg: cfg},
+		"disabled":    {exception: exception("ServiceError", "IOError")},
+		"qualifyType": {exception: exception("IOError").withModule("io"), cfg: m.Config{EmitExceptionTypeHierarchy: true, QualifyExceptionType: true}, expected: []string{"io.IOError"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := Event{Exception: test.exception, config: test.cfg}
+			exceptions := e.fields(&transform.Context{})["exception"].([]common.MapStr)
+			assert.Equal(t, test.expected, exceptions[0]["type_hierarchy"])
+			for _, ex := range exceptions {
+				assert.NotNil(t, ex["message"])
+			}
+			for _, cause := range exceptions[1:] {
+				assert.NotContains(t, cause, "type_hierarchy")
+			}
+		})
+	}
+}
+
+func TestNumericExceptionCode(t *testing.T) {
+	for name, test := range map[string]struct {
+		code     interface{}
+		enabled  bool
+		expected interface{}
+	}{
+		"int":             {code: 500, enabled: true, expected: int64(500)},
+		"float":           {code: 500.0, enabled: true, expected: int64(500)},
+		"fractionalFloat": {code: 500.5, enabled: true},
+		"string":          {code: "500", enabled: true},
+		"jsonNumber":      {code: json.Number("500"), enabled: true, expected: int64(500)},
+		"jsonNumberFloat": {code: json.Number("500.5"), enabled: true},
+		"disabled":        {code: 500},
+	} {
+		t.Run(name, func(t *testing.T) {
+			msg := "exception message"
+			ex := Exception{Message: &msg, Code: test.code}
+			fields := ex.fields(&transform.Context{}, m.Config{EmitNumericExceptionCode: test.enabled})
+			assert.NotNil(t, fields["code"])
+			assert.Equal(t, test.expected, fields["code_numeric"])
+		})
+	}
+}
+
+func TestLogMessageTemplate(t *testing.T) {
+	template := "user %s not found"
+	event := func(msg string, cfg m.Config) Event {
+		return Event{Log: &Log{Message: msg, ParamMessage: &template}, config: cfg}
+	}
+	cfg := m.Config{EmitLogMessageTemplate: true}
+
+	e1, e2 := event("user 123 not found", cfg), event("user 456 not found", cfg)
+	fields := e1.fields(&transform.Context{})
+	assert.Equal(t, template, fields["log"].(common.MapStr)["message_template"])
+	assert.Equal(t, e1.calcGroupingKey(), e2.calcGroupingKey())
+
+	e3 := event("user 123 not found", m.Config{})
+	fields = e3.fields(&transform.Context{})
+	assert.NotContains(t, fields["log"], "message_template")
+
+	e4 := Event{Log: &Log{Message: "user 123 not found"}}
+	e5 := Event{Log: &Log{Message: "user 456 not found"}}
+	assert.NotEqual(t, e4.calcGroupingKey(), e5.calcGroupingKey())
+}
+
+func TestStacktraceFrameCount(t *testing.T) {
+	frames := []*m.StacktraceFrame{{Filename: "a"}, {Filename: "b"}, {Filename: "c"}}
+	e := Event{
+		Exception: baseException().withFrames(frames),
+		Log:       baseLog().withFrames(frames[:2]),
+	}
+	fields := e.fields(&transform.Context{})
+	assert.Equal(t, 3, fields["exception"].([]common.MapStr)[0]["stacktrace_frame_count"])
+	assert.Equal(t, 2, fields["log"].(common.MapStr)["stacktrace_frame_count"])
+
+	e = Event{Exception: baseException(), Log: baseLog()}
+	fields = e.fields(&transform.Context{})
+	assert.NotContains(t, fields["exception"].([]common.MapStr)[0], "stacktrace_frame_count")
+	assert.NotContains(t, fields["log"], "stacktrace_frame_count")
+}
+
+func TestTransformMetrics(t *testing.T) {
+	traceId := "0af7651916cd43dd8448eb211c80319c"
+	for name, test := range map[string]struct {
+		event                                Event
+		withException, withLog, withoutTrace int64
+	}{
+		"exception":       {event: Event{Exception: baseException(), TraceId: &traceId}, withException: 1},
+		"log":             {event: Event{Log: baseLog()}, withLog: 1, withoutTrace: 1},
+		"exceptionAndLog": {event: Event{Exception: baseException(), Log: baseLog(), TraceId: &traceId}, withException: 1, withLog: 1},
+		"neither":         {event: Event{}, withoutTrace: 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			counters := []*monitoring.Int{transformations, withException, withLog, withoutTrace}
+			before := make([]int64, len(counters))
+			for i, c := range counters {
+				before[i] = c.Get()
+			}
+			test.event.Transform(&transform.Context{})
+			expected := []int64{1, test.withException, test.withLog, test.withoutTrace}
+			for i, c := range counters {
+				assert.Equal(t, expected[i], c.Get()-before[i])
+			}
+		})
+	}
+}
+
+func TestOmitStacktraces(t *testing.T) {
+	fct := "fct"
+	frames := []*m.StacktraceFrame{{Filename: "a", Function: &fct, Lineno: 1}, {Filename: "b", Lineno: 2}}
+	event := func(cfg m.Config) Event {
+		return Event{
+			Exception: baseException().withType("type").withFrames(frames),
+			Log:       baseLog().withFrames(frames),
+			config:    cfg,
+		}
+	}
+
+	e := event(m.Config{})
+	fields := e.fields(&transform.Context{})
+	assert.Contains(t, fields["exception"].([]common.MapStr)[0], "stacktrace")
+	assert.Contains(t, fields["log"], "stacktrace")
+	groupingKey := fields["grouping_key"]
+
+	e = event(m.Config{OmitStacktraces: true})
+	fields = e.fields(&transform.Context{})
+	exception := fields["exception"].([]common.MapStr)[0]
+	log := fields["log"].(common.MapStr)
+	assert.NotContains(t, exception, "stacktrace")
+	assert.NotContains(t, log, "stacktrace")
+	assert.Equal(t, 2, exception["stacktrace_frame_count"])
+	assert.Equal(t, 2, log["stacktrace_frame_count"])
+	assert.Equal(t, groupingKey, fields["grouping_key"])
+}
+
+func TestStacktraceModeTopFrame(t *testing.T) {
+	fct := "fct"
+	libraryFrame := true
+	frames := func() []*m.StacktraceFrame {
+		return []*m.StacktraceFrame{
+			{Filename: "lib", Lineno: 1, LibraryFrame: &libraryFrame},
+			{Filename: "a", Function: &fct, Lineno: 2},
+			{Filename: "b", Lineno: 3},
+		}
+	}
+	event := func(frames []*m.StacktraceFrame, cfg m.Config) Event {
+		return Event{
+			Exception: baseException().withType("type").withFrames(frames),
+			Log:       baseLog().withFrames(frames),
+			config:    cfg,
+		}
+	}
+
+	e := event(frames(), m.Config{})
+	fields := e.fields(&transform.Context{})
+	groupingKey := fields["grouping_key"]
+	assert.Len(t, fields["exception"].([]common.MapStr)[0]["stacktrace"], 3)
+
+	e = event(frames(), m.Config{StacktraceMode: "top_frame"})
+	fields = e.fields(&transform.Context{})
+	for _, f := range []common.MapStr{fields["exception"].([]common.MapStr)[0], fields["log"].(common.MapStr)} {
+		st := f["stacktrace"].([]common.MapStr)
+		require.Len(t, st, 1)
+		assert.Equal(t, "a", st[0]["filename"])
+		assert.Equal(t, 3, f["stacktrace_frame_count"])
+	}
+	assert.Equal(t, groupingKey, fields["grouping_key"])
+
+	// the top frame is emitted if all frames are library frames
+	libraryFrames := []*m.StacktraceFrame{
+		{Filename: "lib1", Lineno: 1, LibraryFrame: &libraryFrame},
+		{Filename: "lib2", Lineno: 2, LibraryFrame: &libraryFrame},
+	}
+	e = event(libraryFrames, m.Config{StacktraceMode: "top_frame"})
+	st := e.fields(&transform.Context{})["exception"].([]common.MapStr)[0]["stacktrace"].([]common.MapStr)
+	require.Len(t, st, 1)
+	assert.Equal(t, "lib1", st[0]["filename"])
+
+	// omitting stacktraces takes precedence
+	e = event(frames(), m.Config{StacktraceMode: "top_frame", OmitStacktraces: true})
+	assert.NotContains(t, e.fields(&transform.Context{})["exception"].([]common.MapStr)[0], "stacktrace")
+}
+
+func TestHandledSummary(t *testing.T) {
+	truthy, falsy, exType := true, false, "type"
+	for name, test := range map[string]struct {
+		exception *Exception
+		handled   interface{}
+	}{
+		"noException": {},
+		"nilDefault":  {exception: baseException(), handled: true},
+		"handled":     {exception: &Exception{Message: baseException().Message, Handled: &truthy}, handled: true},
+		"unhandled":   {exception: &Exception{Message: baseException().Message, Handled: &falsy}, handled: false},
+		"mixedChain": {
+			exception: &Exception{
+				Message: baseException().Message,
+				Handled: &truthy,
+				Cause:   []Exception{{Type: &exType}, {Type: &exType, Handled: &falsy}},
+			},
+			handled: false,
+		},
+		"handledChain": {
+			exception: &Exception{
+				Message: baseException().Message,
+				Handled: &truthy,
+				Cause:   []Exception{{Type: &exType}, {Type: &exType, Handled: &truthy}},
+			},
+			handled: true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := Event{Exception: test.exception, Log: baseLog()}
+			fields := e.fields(&transform.Context{})
+			assert.Equal(t, test.handled, fields["handled"])
+		})
+	}
+}
+
+func TestMaxCustomSize(t *testing.T) {
+	custom := m.Custom{"key": strings.Repeat("a", 100)}
+	size := len(`{"key":""}`) + 100
+	for name, test := range map[string]struct {
+		limit   int
+		dropped bool
+	}{
+		"noLimit":       {},
+		"belowLimit":    {limit: size + 1},
+		"atLimit":       {limit: size},
+		"aboveLimit":    {limit: size - 1, dropped: true},
+		"farAboveLimit": {limit: 10, dropped: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			before := customDropped.Get()
+			e := Event{Custom: &custom, config: m.Config{MaxCustomSize: test.limit}}
+			fields := e.fields(&transform.Context{})
+			if test.dropped {
+				assert.NotContains(t, fields, "custom")
+				assert.Equal(t, before+1, customDropped.Get())
+			} else {
+				assert.Equal(t, common.MapStr(custom), fields["custom"])
+				assert.Equal(t, before, customDropped.Get())
+			}
+		})
+	}
+}
+
+func TestLogSeverity(t *testing.T) {
+	severity := func(level string, cfg m.Config) interface{} {
+		log := &Log{Message: "message", Level: &level}
+		return (&Event{Log: log, config: cfg}).fields(&transform.Context{})["log"].(common.MapStr)["severity"]
+	}
+	enabled := m.Config{EmitLogSeverity: true}
+	for level, expected := range map[string]int{
+		"trace": 1, "DEBUG": 5, "Info": 9, "warn": 13, "WARNING": 13, "error": 17, "fatal": 21,
+	} {
+		assert.Equal(t, expected, severity(level, enabled), level)
+	}
+	for _, level := range []string{"custom log level", "", "err"} {
+		assert.Nil(t, severity(level, enabled), level)
+	}
+	assert.Nil(t, severity("error", m.Config{}))
+	assert.Nil(t, (&Event{Log: baseLog(), config: enabled}).fields(&transform.Context{})["log"].(common.MapStr)["severity"])
+
+	custom := m.Config{EmitLogSeverity: true, LogSeverities: map[string]int{"notice": 10, "error": 50}}
+	assert.Equal(t, 10, severity("NOTICE", custom))
+	assert.Equal(t, 50, severity("error", custom))
+	assert.Nil(t, severity("info", custom))
+}
+
+func TestCustomKeysAllowlist(t *testing.T) {
+	custom := m.Custom{
+		"order_id": "1234",
+		"cart":     "cart-42",
+		"email":    "jane@example.com",
+		"card":     "4111111111111111",
+	}
+	for name, test := range map[string]struct {
+		allowlist []string
+		expected  interface{}
+		dropped   int64
+	}{
+		"default":  {expected: common.MapStr(custom)},
+		"filtered": {allowlist: []string{"order_id", "cart", "unknown"}, expected: common.MapStr{"order_id": "1234", "cart": "cart-42"}, dropped: 2},
+		"all":      {allowlist: []string{"order_id", "cart", "email", "card"}, expected: common.MapStr(custom)},
+		"none":     {allowlist: []string{}, dropped: 4},
+	} {
+		t.Run(name, func(t *testing.T) {
+			before := customKeysDropped.Get()
+			e := Event{Custom: &custom, config: m.Config{CustomKeysAllowlist: test.allowlist}}
+			fields := e.fields(&transform.Context{})
+			if test.expected == nil {
+				assert.NotContains(t, fields, "custom")
+			} else {
+				assert.Equal(t, test.expected, fields["custom"])
+			}
+			assert.Equal(t, test.dropped, customKeysDropped.Get()-before)
+			assert.Len(t, custom, 4)
+		})
+	}
+
+	// the size limit applies to the allowed keys only
+	e := Event{Custom: &custom, config: m.Config{CustomKeysAllowlist: []string{"order_id"}, MaxCustomSize: 20}}
+	assert.Equal(t, common.MapStr{"order_id": "1234"}, e.fields(&transform.Context{})["custom"])
+}
+
+func TestTransformRequestLogger(t *testing.T) {
+	require.NoError(t, logp.DevelopmentSetup(logp.ToObserverOutput()))
+	tctx := &transform.Context{LogFields: []interface{}{"request_id", "abc123"}}
+
+	custom := m.Custom{"key": strings.Repeat("a", 100)}
+	e := Event{Custom: &custom, Log: baseLog(), config: m.Config{MaxCustomSize: 10}}
+	e.Transform(tctx)
+
+	frames := make([]interface{}, 5)
+	for i := range frames {
+		frames[i] = map[string]interface{}{"filename": "file", "lineno": 1.0}
+	}
+	input := map[string]interface{}{"exception": map[string]interface{}{"message": "ex", "stacktrace": frames}}
+	transformable, err := DecodeEvent(input, m.Config{MaxStacktraceFrames: 2}, nil)
+	require.NoError(t, err)
+	transformable.Transform(tctx)
+
+	logs := logp.ObserverLogs().TakeAll()
+	require.Len(t, logs, 2)
+	assert.Equal(t, "custom context of error dropped, exceeding 10 bytes", logs[0].Message)
+	assert.Equal(t, "exception stacktrace of error truncated, 3 frames dropped", logs[1].Message)
+	for _, entry := range logs {
+		assert.Equal(t, "transform", entry.LoggerName)
+		assert.Equal(t, []zapcore.Field{zap.String("request_id", "abc123")}, entry.Context)
+	}
+
+	// without request log fields, anomalies are logged without correlation
+	e.Transform(&transform.Context{})
+	logs = logp.ObserverLogs().TakeAll()
+	require.Len(t, logs, 1)
+	assert.Equal(t, "transform", logs[0].LoggerName)
+	assert.Empty(t, logs[0].Context)
+}
+
+func TestStacktraceMetrics(t *testing.T) {
+	frames := []*m.StacktraceFrame{{Filename: "a"}, {Filename: "b"}, {Filename: "c"}}
+	exception := baseException().withFrames(frames)
+	exception.Cause = []Exception{{Message: exception.Message, Stacktrace: frames[:1]}}
+	e := Event{Exception: exception, Log: baseLog().withFrames(frames[:2])}
+
+	counters := []*monitoring.Int{stacktraceCounter, frameCounter,
+		exceptionStacktraceCounter, exceptionFrameCounter, logStacktraceCounter, logFrameCounter}
+	before := make([]int64, len(counters))
+	for i, c := range counters {
+		before[i] = c.Get()
+	}
+	e.Transform(&transform.Context{})
+	for i, expected := range []int64{3, 6, 2, 4, 1, 2} {
+		assert.Equal(t, expected, counters[i].Get()-before[i], i)
+	}
+}
+
+func TestExceptionChainDepthMetrics(t *testing.T) {
+	exception := func(depth int) *Exception {
+		ex := baseException()
+		for i := 1; i < depth; i++ {
+			ex = &Exception{Message: ex.Message, Cause: []Exception{*ex}}
+		}
+		return ex
+	}
+	exceptionChainDepthMax.Set(0)
+	total := exceptionChainDepthTotal.Get()
+
+	(&Event{Exception: exception(3)}).Transform(&transform.Context{})
+	assert.Equal(t, int64(3), exceptionChainDepthMax.Get())
+	assert.Equal(t, int64(3), exceptionChainDepthTotal.Get()-total)
+
+	// shallower chains add to the total only
+	(&Event{Exception: exception(1)}).Transform(&transform.Context{})
+	assert.Equal(t, int64(3), exceptionChainDepthMax.Get())
+	assert.Equal(t, int64(4), exceptionChainDepthTotal.Get()-total)
+
+	// errors without exception are not recorded
+	(&Event{Log: baseLog()}).Transform(&transform.Context{})
+	assert.Equal(t, int64(4), exceptionChainDepthTotal.Get()-total)
+}
+
+func TestCulprit(t *testing.T) {
+	c := "foo"
+	fct := "fct"
+	truthy := true
+	st := m.Stacktrace{
+		&m.StacktraceFrame{Filename: "a", Function: &fct, Sourcemap: m.Sourcemap{}},
+	}
+	stUpdate := m.Stacktrace{
+		&m.StacktraceFrame{Filename: "a", Function: &fct, Sourcemap: m.Sourcemap{}},
+		&m.StacktraceFrame{Filename: "a", LibraryFrame: &truthy, Sourcemap: m.Sourcemap{Updated: &truthy}},
+		&m.StacktraceFrame{Filename: "f", Function: &fct, Sourcemap: m.Sourcemap{Updated: &truthy}},
+		&m.StacktraceFrame{Filename: "bar", Function: &fct, Sourcemap: m.Sourcemap{Updated: &truthy}},
+	}
+	mapper := sourcemap.SmapMapper{}
+	tests := []struct {
+		event   Event
+		config  transform.Config
+		culprit string
+		msg     string
+	}{
+		{
+			event:   Event{Culprit: &c},
+			config:  transform.Config{},
+			culprit: "foo",
+			msg:     "No Sourcemap in config",
+		},
+		{
+			event:   Event{Culprit: &c},
+			config:  transform.Config{SmapMapper: &mapper},
+			culprit: "foo",
+			msg:     "No Stacktrace Frame given.",
+		},
+		{
+			event:   Event{Culprit: &c, Log: &Log{Stacktrace: st}},
+			config:  transform.Config{SmapMapper: &mapper},
+			culprit: "foo",
+			msg:     "Log.StacktraceFrame has no updated frame",
+		},
+		{
+			event: Event{
+				Culprit: &c,
+				Log: &Log{
+					Stacktrace: m.Stacktrace{
+						&m.StacktraceFrame{
+							Filename:  "f",
+							Sourcemap: m.Sourcemap{Updated: &truthy},
+						},
+					},
+				},
+			},
+			config:  transform.Config{SmapMapper: &mapper},
+			culprit: "f",
+			msg:     "Adapt culprit to first valid Log.StacktraceFrame information.",
+		},
+		{
+			event: Event{
+				Culprit:   &c,
+				Exception: &Exception{Stacktrace: stUpdate},
+			},
+			config:  transform.Config{SmapMapper: &mapper},
+			culprit: "f in fct",
+			msg:     "Adapt culprit to first valid Exception.StacktraceFrame information.",
+		},
+		{
+			event: Event{
+				Culprit:   &c,
+				Log:       &Log{Stacktrace: st},
+				Exception: &Exception{Stacktrace: stUpdate},
+			},
+			config:  transform.Config{SmapMapper: &mapper},
+			culprit: "f in fct",
+			msg:     "Log and Exception StacktraceFrame given, only one changes culprit.",
+		},
+		{
+			event: Event{
+				Culprit: &c,
+				Log: &Log{
+					Stacktrace: m.Stacktrace{
+						&m.StacktraceFrame{
+							Filename:  "a",
+							Function:  &fct,
+							Sourcemap: m.Sourcemap{Updated: &truthy},
+						},
+					},
+				},
+				Exception: &Exception{Stacktrace: stUpdate},
+			},
+			config:  transform.Config{SmapMapper: &mapper},
+			culprit: "a in fct",
+			msg:     "Log Stacktrace is prioritized over Exception StacktraceFrame",
+		},
+		{
+			event:   Event{Culprit: &c, Exception: &Exception{Stacktrace: stUpdate}},
+			config:  transform.Config{},
+			culprit: "foo",
+			msg:     "No Sourcemap in config, agent culprit is kept",
+		},
+		{
+			event:   Event{Exception: &Exception{Stacktrace: stUpdate[1:]}},
+			config:  transform.Config{},
+			culprit: "f in fct",
+			msg:     "No Sourcemap in config, culprit from first non library Exception.StacktraceFrame",
+		},
+		{
+			event: Event{
+				Log:       &Log{Stacktrace: m.Stacktrace{&m.StacktraceFrame{Filename: "b"}}},
+				Exception: &Exception{Stacktrace: stUpdate},
+			},
+			config:  transform.Config{},
+			culprit: "b",
+			msg:     "No Sourcemap in config, Log Stacktrace is prioritized over Exception StacktraceFrame",
+		},
+	}
+	for idx, test := range tests {
+		tctx := &transform.Context{
+			Config: test.config,
+		}
+
+		culprit, _ := test.event.culprit(tctx)
+		assert.Equal(t, test.culprit, *culprit,
+			fmt.Sprintf("(%v) expected <%v>, received <%v>", idx, test.culprit, *culprit))
+	}
+
+	e := Event{Exception: &Exception{Stacktrace: m.Stacktrace{
+		&m.StacktraceFrame{Filename: "lib", LibraryFrame: &truthy},
+	}}}
+	culprit, _ := e.culprit(&transform.Context{})
+	assert.Nil(t, culprit, "only library frames given")
+}
+
+func TestOriginalCulprit(t *testing.T) {
+	truthy := true
+	fct := "fct"
+	minified, derived := "a.min.js in b", "f in fct"
+	st := m.Stacktrace{&m.StacktraceFrame{Filename: "f", Function: &fct, Sourcemap: m.Sourcemap{Updated: &truthy}}}
+	mapper := sourcemap.SmapMapper{}
+	smapTctx := &transform.Context{Config: transform.Config{SmapMapper: &mapper}}
+
+	for name, test := range map[string]struct {
+		culprit  *string
+		tctx     *transform.Context
+		expected string
+		original interface{}
+	}{
+		"override":        {culprit: &minified, tctx: smapTctx, expected: derived, original: minified},
+		"noAgentCulprit":  {tctx: smapTctx, expected: derived},
+		"sameCulprit":     {culprit: &derived, tctx: smapTctx, expected: derived},
+		"noSourcemapping": {culprit: &minified, tctx: &transform.Context{}, expected: minified},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := Event{Culprit: test.culprit, Exception: &Exception{Stacktrace: st}}
+			// the culprit is derived from the agent's culprit every time
+			for i := 0; i < 2; i++ {
+				fields := e.fields(test.tctx)
+				assert.Equal(t, test.expected, fields["culprit"])
+				assert.Equal(t, test.original, fields["original_culprit"])
+			}
+			assert.Equal(t, test.culprit, e.Culprit)
+		})
+	}
+}
+
+func TestCulpritMaxLength(t *testing.T) {
+	truthy := true
+	fct := "function"
+	filename := "webpack:///bundle.4f3a2b1c9d8e7f6a5b4c3d2e1f0a9b8c.js"
+	st := m.Stacktrace{&m.StacktraceFrame{Filename: filename, Function: &fct, Sourcemap: m.Sourcemap{Updated: &truthy}}}
+	agentCulprit := strings.Repeat("c", 100)
+	mapper := sourcemap.SmapMapper{}
+
+	for name, test := range map[string]struct {
+		culprit  *string
+		limit    int
+		expected string
+	}{
+		"noLimit":      {expected: filename + " in function"},
+		"underLimit":   {limit: 100, expected: filename + " in function"},
+		"overLimit":    {limit: 20, expected: "webpack:///bundle..."},
+		"agentCulprit": {culprit: &agentCulprit, limit: 20, expected: "webpack:///bundle..."},
+		"tinyLimit":    {limit: 2, expected: "we"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := Event{Culprit: test.culprit, Exception: &Exception{Stacktrace: st}, config: m.Config{MaxCulpritLength: test.limit}}
+			culprit, _ := e.culprit(&transform.Context{Config: transform.Config{SmapMapper: &mapper}})
+			require.NotNil(t, culprit)
+			assert.Equal(t, test.expected, *culprit)
+		})
+	}
+
+	// culprits sent by the agent are kept as they are
+	e := Event{Culprit: &agentCulprit, config: m.Config{MaxCulpritLength: 20}}
+	culprit, _ := e.culprit(&transform.Context{})
+	assert.Equal(t, agentCulprit, *culprit)
+}
+
+func TestCulpritTemplate(t *testing.T) {
+	fct := "handle"
+	withFunction := &m.StacktraceFrame{Filename: "main.go", Lineno: 42, Function: &fct}
+	withoutFunction := &m.StacktraceFrame{Filename: "main.go", Lineno: 42}
+
+	for name, test := range map[string]struct {
+		template string
+		frame    *m.StacktraceFrame
+		expected string
+	}{
+		"default":             {frame: withFunction, expected: "main.go in handle"},
+		"defaultNoFunction":   {frame: withoutFunction, expected: "main.go"},
+		"functionFileLine":    {template: "{{.Function}} ({{.Filename}}:{{.Lineno}})", frame: withFunction, expected: "handle (main.go:42)"},
+		"functionOnly":        {template: "{{.Function}}", frame: withFunction, expected: "handle"},
+		"conditionalFunction": {template: "{{if .Function}}{{.Function}}{{else}}{{.Filename}}{{end}}", frame: withoutFunction, expected: "main.go"},
+		"executionError":      {template: "{{.Unknown}}", frame: withFunction, expected: "main.go in handle"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var cfg m.Config
+			if test.template != "" {
+				cfg.CulpritTemplate = template.Must(template.New("culprit").Parse(test.template))
+			}
+			e := Event{Exception: &Exception{Stacktrace: m.Stacktrace{test.frame}}, config: cfg}
+			culprit, _ := e.culprit(&transform.Context{})
+			require.NotNil(t, culprit)
+			assert.Equal(t, test.expected, *culprit)
+		})
+	}
+}
+
+func TestEmptyGroupingKey(t *testing.T) {
+	e := Event{}
+	assert.Equal(t, "", e.calcGroupingKey())
+	assert.Equal(t, "", GroupingKey(m.Config{}, nil, nil, nil, nil))
+
+	// scopes are not content to group by
+	service := "service"
+	e = Event{config: m.Config{GroupingKeyIncludeService: true, GroupingKeyVersion: 2}}
+	assert.Equal(t, "", e.calcServiceGroupingKey(&service))
+
+	for name, e := range map[string]Event{
+		"empty":           {},
+		"emptyException":  {Exception: &Exception{}},
+		"emptyStacktrace": {Exception: &Exception{Stacktrace: m.Stacktrace{}}, config: m.Config{GroupingKeyVersion: 1}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			fields := e.fields(&transform.Context{})
+			assert.NotContains(t, fields, "grouping_key")
+			assert.NotContains(t, fields, "grouping_key_source")
+			assert.NotContains(t, fields, "grouping_key_version")
+		})
+	}
+
+	// an empty message is still grouped by
+	e = Event{Log: &Log{}}
+	assert.Equal(t, hex.EncodeToString(md5.New().Sum(nil)), e.calcGroupingKey())
+	assert.Contains(t, e.fields(&transform.Context{}), "grouping_key")
+}
+
+func TestGroupingKeyHash(t *testing.T) {
+	exType := "DbError"
+	for algorithm, h := range map[string]hash.Hash{
+		"":       md5.New(),
+		"md5":    md5.New(),
+		"sha1":   sha1.New(),
+		"sha256": sha256.New(),
+	} {
+		t.Run(algorithm, func(t *testing.T) {
+			io.WriteString(h, exType)
+			expected := hex.EncodeToString(h.Sum(nil))
+
+			e1 := Event{Exception: baseException().withType(exType), config: m.Config{GroupingKeyHash: algorithm}}
+			e2 := Event{Exception: baseException().withType(exType), config: m.Config{GroupingKeyHash: algorithm}}
+			assert.Equal(t, expected, e1.calcGroupingKey())
+			assert.Equal(t, e1.calcGroupingKey(), e2.calcGroupingKey())
+		})
+	}
+
+	input := map[string]interface{}{"exception": map[string]interface{}{"type": exType}}
+	for _, algorithm := range []string{"SHA256", "sha-256", "crc32"} {
+		assert.Error(t, m.Config{GroupingKeyHash: algorithm}.Validate(), algorithm)
+		_, _, err := PreviewGroupingKey(input, m.Config{GroupingKeyHash: algorithm})
+		assert.Error(t, err, algorithm)
+	}
+
+	// the configuration is validated once, errors are not rejected one by one
+	before := decodeErrorsValidation.Get()
+	_, err := DecodeEvent(input, m.Config{GroupingKeyHash: "crc32"}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, before, decodeErrorsValidation.Get())
+}
+
+func TestGroupingKeyEncoding(t *testing.T) {
+	exType := "DbError"
+	digest := md5With(exType)
+	for _, algorithm := range []string{"md5", "sha256"} {
+		event := func(encoding string) *Event {
+			return &Event{Exception: baseException().withType(exType), config: m.Config{GroupingKeyHash: algorithm, GroupingKeyEncoding: encoding}}
+		}
+		hexKey := event("hex").calcGroupingKey()
+		assert.Equal(t, hexKey, event("").calcGroupingKey())
+		base64Key := event("base64").calcGroupingKey()
+		assert.NotEqual(t, hexKey, base64Key)
+		assert.True(t, len(base64Key) < len(hexKey))
+
+		fromHex, err := hex.DecodeString(hexKey)
+		require.NoError(t, err)
+		fromBase64, err := base64.StdEncoding.DecodeString(base64Key)
+		require.NoError(t, err)
+		assert.Equal(t, fromHex, fromBase64)
+		if algorithm == "md5" {
+			assert.Equal(t, digest, fromBase64)
+		}
+	}
+
+	// empty keys stay empty
+	assert.Equal(t, "", (&Event{config: m.Config{GroupingKeyEncoding: "base64"}}).calcGroupingKey())
+	assert.Equal(t, base64.StdEncoding.EncodeToString(digest),
+		GroupingKey(m.Config{GroupingKeyEncoding: "base64"}, &exType, nil, nil, nil))
+}
+
+func TestExceptionMessageHash(t *testing.T) {
+	messageHash := func(ex *Exception, cfg m.Config) interface{} {
+		e := Event{Exception: ex, config: cfg}
+		return e.Document(nil)["error"].(common.MapStr)["exception"].([]common.MapStr)[0]["message_hash"]
+	}
+	exception := func(msg string) *Exception { return &Exception{Message: &msg} }
+	exType := "type"
+	md5Cfg := m.Config{ExceptionMessageHash: "md5"}
+
+	assert.Nil(t, messageHash(exception("user 1 not found"), m.Config{}))
+	assert.Equal(t, hex.EncodeToString(md5With("user 1 not found")), messageHash(exception("user 1 not found"), md5Cfg))
+	assert.Nil(t, messageHash(exception(""), md5Cfg))
+	assert.Nil(t, messageHash(&Exception{Type: &exType}, md5Cfg))
+
+	h := sha256.New()
+	io.WriteString(h, "user 1 not found")
+	assert.Equal(t, hex.EncodeToString(h.Sum(nil)), messageHash(exception("user 1 not found"), m.Config{ExceptionMessageHash: "sha256"}))
+
+	// the hash is taken from the message before redaction
+	redacted := m.Config{ExceptionMessageHash: "md5", RedactionRules: []m.RedactionRule{{Pattern: regexp.MustCompile(`\d+`), Replacement: "*"}}}
+	assert.Equal(t, messageHash(exception("user 1 not found"), md5Cfg), messageHash(exception("user 1 not found"), redacted))
+
+	assert.NotEqual(t, messageHash(exception("user 1 not found"), md5Cfg), messageHash(exception("user 2 not found"), md5Cfg))
+	normalize := m.Config{ExceptionMessageHash: "md5", ExceptionMessageHashNormalize: true}
+	assert.Equal(t, messageHash(exception("user 1 not found"), normalize), messageHash(exception("user 2 not found"), normalize))
+	assert.NotEqual(t, messageHash(exception("user 1 not found"), normalize), messageHash(exception("user 1 deleted"), normalize))
+
+	for _, algorithm := range []string{"MD5", "sha-1", "crc32"} {
+		assert.Error(t, m.Config{ExceptionMessageHash: algorithm}.Validate(), algorithm)
+	}
+	for _, algorithm := range []string{"", "md5", "sha1", "sha256"} {
+		assert.NoError(t, m.Config{ExceptionMessageHash: algorithm, GroupingKeyHash: algorithm}.Validate(), algorithm)
+	}
+}
+
+func TestGroupingKeyPooled(t *testing.T) {
+	algorithms := map[string]func() hash.Hash{"md5": md5.New, "sha1": sha1.New, "sha256": sha256.New}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for algorithm, newHash := range algorithms {
+			wg.Add(1)
+			go func(algorithm string, newHash func() hash.Hash, i int) {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					exType := fmt.Sprintf("Error%d_%d", i, j)
+					h := newHash()
+					io.WriteString(h, exType)
+					e := Event{Exception: baseException().withType(exType), config: m.Config{GroupingKeyHash: algorithm}}
+					assert.Equal(t, hex.EncodeToString(h.Sum(nil)), e.calcGroupingKey())
+				}
+			}(algorithm, newHash, i)
+		}
+	}
+	wg.Wait()
+
+	// a released key does not carry state into the next one
+	e := Event{config: m.Config{RecordGroupingComponents: true}}
+	assert.Equal(t, "", e.calcGroupingKey())
+	assert.Equal(t, []string{}, e.groupingComponents)
+	exType := "DbError"
+	e = Event{Exception: baseException().withType(exType), config: m.Config{RecordGroupingComponents: true}}
+	assert.Equal(t, hex.EncodeToString(md5With(exType)), e.calcGroupingKey())
+	assert.Equal(t, []string{exType}, e.groupingComponents)
+}
+
+func TestGroupingKey(t *testing.T) {
+	exType, paramMsg, msg, fn := "DbError", "user %s not found", "user 1 not found", "query"
+	st := m.Stacktrace{&m.StacktraceFrame{Filename: "db.go", Function: &fn}}
+
+	for name, test := range map[string]struct {
+		exType, paramMsg, msg *string
+		st                    m.Stacktrace
+		key                   string
+	}{
+		"empty": {},
+		"message fallback": {
+			msg: &msg,
+			key: hex.EncodeToString(md5With(msg)),
+		},
+		"message fallback with empty stacktrace": {
+			msg: &msg, st: m.Stacktrace{},
+			key: hex.EncodeToString(md5With(msg)),
+		},
+		"no message fallback": {
+			exType: &exType, paramMsg: &paramMsg, msg: &msg, st: st,
+			key: hex.EncodeToString(md5With(exType, paramMsg, "db.go", fn)),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.key, GroupingKey(m.Config{}, test.exType, test.paramMsg, test.msg, test.st))
+		})
+	}
+
+	e := Event{Exception: baseException().withType(exType), Log: baseLog().withParamMsg(paramMsg).withFrames(st)}
+	assert.Equal(t, e.calcGroupingKey(), GroupingKey(m.Config{}, &exType, &paramMsg, e.Exception.Message, st))
+}
+
+func TestExplicitGroupingKey(t *testing.T) {
+	attr := "hello world"
+	diffAttr := "huhu"
+
+	groupingKey := hex.EncodeToString(md5With(attr))
+
+	e1 := Event{Log: baseLog().withParamMsg(attr)}
+	e2 := Event{Exception: baseException().withType(attr)}
+	e3 := Event{Log: baseLog().withFrames([]*m.StacktraceFrame{{Function: &attr}})}
+	e4 := Event{Exception: baseException().withFrames([]*m.StacktraceFrame{{Function: &attr}})}
+	e5 := Event{
+		Log:       baseLog().withFrames([]*m.StacktraceFrame{{Function: &diffAttr}}),
+		Exception: baseException().withFrames([]*m.StacktraceFrame{{Function: &attr}}),
+	}
+
+	for idx, e := range []Event{e1, e2, e3, e4, e5} {
+		assert.Equal(t, groupingKey, e.calcGroupingKey(), "grouping_key mismatch", idx)
+	}
+}
+
+func TestProvidedGroupingKey(t *testing.T) {
+	provided := "dc9ed07b49de3c9d15d6b3f958dfea98"
+	e := Event{Exception: baseException().withType("type"), GroupingKey: &provided}
+	fields := e.fields(&transform.Context{})
+	assert.Equal(t, provided, fields["grouping_key"])
+	assert.Equal(t, "provided", fields["grouping_key_source"])
+
+	e.GroupingKey = nil
+	fields = e.fields(&transform.Context{})
+	assert.Equal(t, e.calcGroupingKey(), fields["grouping_key"])
+	assert.NotEqual(t, provided, fields["grouping_key"])
+	assert.Equal(t, "computed", fields["grouping_key_source"])
+}
+
+func TestExceptionChainGroupingKey(t *testing.T) {
+	wrapper, rootType, otherRootType := "WrapperException", "RootException", "OtherRootException"
+	module, wrapperFn, rootFn := "module", "wrap", "fail"
+	chain := func(root string) Event {
+		return Event{Exception: &Exception{
+			Type:       &wrapper,
+			Stacktrace: m.Stacktrace{&m.StacktraceFrame{Module: &module, Function: &wrapperFn}},
+			Cause: []Exception{{
+				Type:       &root,
+				Stacktrace: m.Stacktrace{&m.StacktraceFrame{Module: &module, Function: &rootFn}},
+			}},
+		}}
+	}
+	e1, e2, e3 := chain(rootType), chain(rootType), chain(otherRootType)
+
+	assert.Equal(t,
+		hex.EncodeToString(md5With(wrapper, rootType, module, wrapperFn, module, rootFn)),
+		e1.calcGroupingKey())
+	assert.Equal(t, e1.calcGroupingKey(), e2.calcGroupingKey())
+	assert.NotEqual(t, e1.calcGroupingKey(), e3.calcGroupingKey())
+}
+
+func TestExceptionChainGroupingKeyPreferRootCause(t *testing.T) {
+	wrapper, otherWrapper := "WrapperException", "OtherWrapperException"
+	rootType, otherRootType := "RootException", "OtherRootException"
+	module, fn := "module", "fail"
+	chain := func(wrapper, root string) Event {
+		return Event{
+			Exception: &Exception{
+				Type: &wrapper,
+				Cause: []Exception{{
+					Type:       &root,
+					Stacktrace: m.Stacktrace{&m.StacktraceFrame{Module: &module, Function: &fn}},
+				}},
+			},
+			config: m.Config{GroupingKeyPreferRootCause: true},
+		}
+	}
+	e1, e2 := chain(wrapper, rootType), chain(wrapper, otherRootType)
+	e3 := chain(otherWrapper, rootType)
+
+	assert.Equal(t, hex.EncodeToString(md5With(rootType, module, fn)), e1.calcGroupingKey())
+	assert.NotEqual(t, e1.calcGroupingKey(), e2.calcGroupingKey())
+	assert.Equal(t, e1.calcGroupingKey(), e3.calcGroupingKey())
+}
+
+func TestExceptionChainMaxDepth(t *testing.T) {
+	msg := "cyclic"
+	cyclic := make([]Exception, 1)
+	cyclic[0] = Exception{Message: &msg}
+	cyclic[0].Cause = cyclic
+
+	assert.Len(t, cyclic[0].flatten(defaultMaxExceptionCauseDepth), defaultMaxExceptionCauseDepth)
+	assert.Len(t, cyclic[0].flatten(3), 3)
+
+	e := Event{Exception: &cyclic[0], config: m.Config{MaxExceptionCauseDepth: 5}}
+	assert.Len(t, e.exceptionChain(), 5)
+}
+
+func TestGroupingKeyIgnoreLineno(t *testing.T) {
+	filename := "file"
+	for _, test := range []struct {
+		cfg       m.Config
+		sameGroup bool
+	}{
+		{cfg: m.Config{}, sameGroup: false},
+		{cfg: m.Config{GroupingKeyIgnoreLineno: true}, sameGroup: true},
+	} {
+		e1 := Event{
+			Exception: baseException().withFrames([]*m.StacktraceFrame{{Filename: filename, Lineno: 10}}),
+			config:    test.cfg,
+		}
+		e2 := Event{
+			Exception: baseException().withFrames([]*m.StacktraceFrame{{Filename: filename, Lineno: 57}}),
+			config:    test.cfg,
+		}
+		assert.Equal(t, test.sameGroup, e1.calcGroupingKey() == e2.calcGroupingKey(), test.cfg)
+	}
+}
+
+func TestGroupingKeyExcludeLibraryFrames(t *testing.T) {
+	truthy, falsy := true, false
+	appFrame := &m.StacktraceFrame{Filename: "app", Lineno: 1}
+	libFrames := func(lineno int) []*m.StacktraceFrame {
+		return []*m.StacktraceFrame{
+			{Filename: "lib", Lineno: lineno, LibraryFrame: &truthy},
+			appFrame,
+			{Filename: "app2", Lineno: 2, LibraryFrame: &falsy},
+		}
+	}
+	for _, test := range []struct {
+		cfg       m.Config
+		sameGroup bool
+	}{
+		{cfg: m.Config{}, sameGroup: false},
+		{cfg: m.Config{GroupingKeyExcludeLibraryFrames: true}, sameGroup: true},
+	} {
+		e1 := Event{Exception: baseException().withFrames(libFrames(10)), config: test.cfg}
+		e2 := Event{Exception: baseException().withFrames(libFrames(57)), config: test.cfg}
+		assert.Equal(t, test.sameGroup, e1.calcGroupingKey() == e2.calcGroupingKey(), test.cfg)
+	}
+
+	cfg := m.Config{GroupingKeyExcludeLibraryFrames: true}
+	e := Event{Exception: baseException().withFrames(libFrames(10)), config: cfg}
+	appOnly := Event{
+		Exception: baseException().withFrames([]*m.StacktraceFrame{appFrame, {Filename: "app2", Lineno: 2}}),
+		config:    cfg,
+	}
+	assert.Equal(t, appOnly.calcGroupingKey(), e.calcGroupingKey())
+}
+
+func TestQualifyExceptionType(t *testing.T) {
+	module, bare, qualified := "my.module", "Error", "my.module.Error"
+	empty := ""
+	for name, test := range map[string]struct {
+		exType, module *string
+		cfg            m.Config
+		expected       *string
+	}{
+		"disabled":         {exType: &bare, module: &module, expected: &bare},
+		"needsJoining":     {exType: &bare, module: &module, cfg: m.Config{QualifyExceptionType: true}, expected: &qualified},
+		"alreadyQualified": {exType: &qualified, module: &module, cfg: m.Config{QualifyExceptionType: true}, expected: &qualified},
+		"noModule":         {exType: &bare, cfg: m.Config{QualifyExceptionType: true}, expected: &bare},
+		"emptyModule":      {exType: &bare, module: &empty, cfg: m.Config{QualifyExceptionType: true}, expected: &bare},
+		"noType":           {module: &module, cfg: m.Config{QualifyExceptionType: true}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			e := Event{Exception: &Exception{Type: test.exType, Module: test.module}, config: test.cfg}
+			fields := e.fields(&transform.Context{})
+			ex := fields["exception"].([]common.MapStr)[0]
+			if test.expected == nil {
+				assert.NotContains(t, ex, "type")
+			} else {
+				assert.Equal(t, *test.expected, ex["type"])
+			}
+			if test.module == &module {
+				assert.Equal(t, module, ex["module"])
+			}
+		})
+	}
+
+	// grouping uses the qualified type, so both forms end up in the same group
+	cfg := m.Config{QualifyExceptionType: true}
+	e1 := Event{Exception: &Exception{Type: &bare, Module: &module}, config: cfg}
+	e2 := Event{Exception: &Exception{Type: &qualified, Module: &module}, config: cfg}
+	assert.Equal(t, e1.calcGroupingKey(), e2.calcGroupingKey())
+	e1.config, e2.config = m.Config{}, m.Config{}
+	assert.NotEqual(t, e1.calcGroupingKey(), e2.calcGroupingKey())
+}
+
+func TestGroupingKeyIncludeLibraryFlag(t *testing.T) {
+	truthy := true
+	for _, test := range []struct {
+		cfg       m.Config
+		sameGroup bool
+	}{
+		{cfg: m.Config{}, sameGroup: true},
+		{cfg: m.Config{GroupingKeyIncludeLibraryFlag: true}, sameGroup: false},
+	} {
+		e1 := Event{
+			Exception: baseException().withFrames([]*m.StacktraceFrame{{Filename: "file", Lineno: 10}}),
+			config:    test.cfg,
+		}
+		e2 := Event{
+			Exception: baseException().withFrames([]*m.StacktraceFrame{{Filename: "file", Lineno: 10, LibraryFrame: &truthy}}),
+			config:    test.cfg,
+		}
+		assert.Equal(t, test.sameGroup, e1.calcGroupingKey() == e2.calcGroupingKey(), test.cfg)
+	}
+}
+
+func TestGroupingKeyIncludeService(t *testing.T) {
+	serviceA, serviceB := "service-a", "service-b"
+	event := func(service *string, cfg m.Config) *Event {
+		return &Event{
+			Exception: baseException().withType("type").withFrames([]*m.StacktraceFrame{{Filename: "file", Lineno: 1}}),
+			Service:   &metadata.Service{Name: service},
+			config:    cfg,
+		}
+	}
+	groupingKey := func(e *Event, tctx *transform.Context) interface{} {
+		return e.fields(tctx)["grouping_key"]
+	}
+	tctx := &transform.Context{}
+	cfg := m.Config{GroupingKeyIncludeService: true}
+
+	assert.Equal(t, groupingKey(event(&serviceA, m.Config{}), tctx), groupingKey(event(&serviceB, m.Config{}), tctx))
+	assert.NotEqual(t, groupingKey(event(&serviceA, cfg), tctx), groupingKey(event(&serviceB, cfg), tctx))
+	assert.Equal(t, groupingKey(event(&serviceA, cfg), tctx), groupingKey(event(&serviceA, cfg), tctx))
+
+	// the service name is taken from the metadata if not sent with the event
+	metadataTctx := &transform.Context{Metadata: metadata.Metadata{Service: &metadata.Service{Name: &serviceA}}}
+	assert.Equal(t, groupingKey(event(&serviceA, cfg), tctx), groupingKey(event(nil, cfg), metadataTctx))
+
+	// errors without service still fall back to the message
+	e1 := Event{Log: &Log{Message: "a"}, config: cfg}
+	e2 := Event{Log: &Log{Message: "b"}, config: cfg}
+	assert.NotEqual(t, groupingKey(&e1, tctx), groupingKey(&e2, tctx))
+}
+
+func TestDisableGroupingKey(t *testing.T) {
+	providedKey := "dc9ed07b49de3c9d15d6b3f958dfea98"
+	for name, e := range map[string]*Event{
+		"computed": {Exception: baseException().withType("type")},
+		"provided": {Log: baseLog(), GroupingKey: &providedKey},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Contains(t, e.fields(&transform.Context{}), "grouping_key")
+
+			e.config = m.Config{DisableGroupingKey: true, GroupingKeyVersion: 2}
+			fields := e.fields(&transform.Context{})
+			for _, key := range []string{"grouping_key", "grouping_key_source", "grouping_key_version"} {
+				assert.NotContains(t, fields, key)
+			}
+		})
+	}
+}
+
+func TestGroupingKeyVersion(t *testing.T) {
+	event := func(version int) *Event {
+		return &Event{
+			Exception: baseException().withType("type").withFrames([]*m.StacktraceFrame{{Filename: "file", Lineno: 1}}),
+			config:    m.Config{GroupingKeyVersion: version},
+		}
+	}
+	tctx := &transform.Context{}
+	unversioned := event(0).fields(tctx)
+	assert.NotContains(t, unversioned, "grouping_key_version")
+
+	v1 := event(1).fields(tctx)
+	assert.Equal(t, unversioned["grouping_key"], v1["grouping_key"])
+	assert.Equal(t, 1, v1["grouping_key_version"])
+
+	v2 := event(2).fields(tctx)
+	assert.NotEqual(t, v1["grouping_key"], v2["grouping_key"])
+	assert.Equal(t, v2["grouping_key"], event(2).fields(tctx)["grouping_key"])
+	assert.Equal(t, 2, v2["grouping_key_version"])
+	assert.NotEqual(t, v2["grouping_key"], event(3).fields(tctx)["grouping_key"])
+
+	// provided grouping keys are not versioned
+	providedKey := "dc9ed07b49de3c9d15d6b3f958dfea98"
+	provided := event(2)
+	provided.GroupingKey = &providedKey
+	fields := provided.fields(tctx)
+	assert.Equal(t, providedKey, fields["grouping_key"])
+	assert.NotContains(t, fields, "grouping_key_version")
+}
+
+func TestGroupingKeyNormalizePaths(t *testing.T) {
+	event := func(filename string, cfg m.Config) *Event {
+		return &Event{
+			Exception: baseException().withType("type").withFrames([]*m.StacktraceFrame{
+				{Filename: filename, Lineno: 1},
+			}),
+			config: cfg,
+		}
+	}
+	windows, unix := `src\app\handler.go`, "src/app/handler.go"
+	cfg := m.Config{GroupingKeyNormalizePaths: true}
+
+	assert.NotEqual(t, event(windows, m.Config{}).calcGroupingKey(), event(unix, m.Config{}).calcGroupingKey())
+	assert.Equal(t, event(windows, cfg).calcGroupingKey(), event(unix, cfg).calcGroupingKey())
+	// unix paths are not affected by the normalization
+	assert.Equal(t, event(unix, m.Config{}).calcGroupingKey(), event(unix, cfg).calcGroupingKey())
+	assert.NotEqual(t, event(windows, cfg).calcGroupingKey(), event("src/app/other.go", cfg).calcGroupingKey())
+
+	// the emitted stacktrace keeps the original filename
+	fields := event(windows, cfg).fields(&transform.Context{})
+	frames := fields["exception"].([]common.MapStr)[0]["stacktrace"].([]common.MapStr)
+	assert.Equal(t, windows, frames[0]["filename"])
+}
+
+func TestGroupingKeyFilenameBasename(t *testing.T) {
+	event := func(cfg m.Config, filenames ...string) *Event {
+		var frames []*m.StacktraceFrame
+		for idx, filename := range filenames {
+			frames = append(frames, &m.StacktraceFrame{Filename: filename, Lineno: idx})
+		}
+		return &Event{Exception: baseException().withType("type").withFrames(frames), config: cfg}
+	}
+	run1 := []string{"/home/runner/work/1/app/handler.go", "/home/runner/work/1/app/main.go"}
+	run2 := []string{"/home/runner/work/2/app/handler.go", "/home/runner/work/2/app/main.go"}
+	cfg := m.Config{GroupingKeyFilenameBasename: true}
+
+	assert.NotEqual(t, event(m.Config{}, run1...).calcGroupingKey(), event(m.Config{}, run2...).calcGroupingKey())
+	assert.Equal(t, event(cfg, run1...).calcGroupingKey(), event(cfg, run2...).calcGroupingKey())
+	assert.Equal(t, event(cfg, run1...).calcGroupingKey(), event(m.Config{}, "handler.go", "main.go").calcGroupingKey())
+	assert.Equal(t, event(cfg, run1...).calcGroupingKey(), event(cfg, `C:\work\handler.go`, "main.go").calcGroupingKey())
+	assert.NotEqual(t, event(cfg, run1...).calcGroupingKey(), event(cfg, "/home/runner/work/1/app/other.go", "main.go").calcGroupingKey())
+	assert.Equal(t, event(cfg, "").calcGroupingKey(), event(m.Config{}, "").calcGroupingKey())
+
+	// the emitted stacktrace keeps the original filename
+	fields := event(cfg, run1...).fields(&transform.Context{})
+	frames := fields["exception"].([]common.MapStr)[0]["stacktrace"].([]common.MapStr)
+	assert.Equal(t, run1[0], frames[0]["filename"])
+}
+
+func TestGroupingKeyIncludeStatusClass(t *testing.T) {
+	event := func(statusCode *int, cfg m.Config) *Event {
+		e := &Event{
+			Exception: baseException().withType("type").withFrames([]*m.StacktraceFrame{{Filename: "file", Lineno: 1}}),
+			config:    cfg,
+		}
+		if statusCode != nil {
+			e.Http = &m.Http{Response: &m.Resp{StatusCode: statusCode}}
+		}
+		return e
+	}
+	notFound, gone, internal := 404, 410, 500
+	cfg := m.Config{GroupingKeyIncludeStatusClass: true}
 
-			Output: common.MapStr{
-				"labels":     common.MapStr{"key": true, "label": 101},
-				"service":    common.MapStr{"name": "myservice"},
-				"agent":      common.MapStr{"name": "go", "version": "1.0"},
-				"user":       common.MapStr{"email": email},
-				"client":     common.MapStr{"ip": userIp},
-				"user_agent": common.MapStr{"original": userAgent},
-				"error": common.MapStr{
-					"custom": common.MapStr{
-						"foo": "bar",
-					},
-					"grouping_key": "1d1e44ffdf01cad5117a72fd42e4fdf4",
-					"log":          common.MapStr{"message": "error log message"},
-					"exception": []common.MapStr{{
-						"message": "exception message",
-						"stacktrace": []common.MapStr{{
-							"exclude_from_grouping": false,
-							"filename":              "myFile",
-							"line":                  common.MapStr{"number": 0},
-							"sourcemap": common.MapStr{
-								"error":   "Colno mandatory for sourcemapping.",
-								"updated": false,
-							},
-						}},
-					}},
-					"page": common.MapStr{"url": url, "referer": referer},
-				},
-				"processor":   common.MapStr{"event": "error", "name": "error"},
-				"transaction": common.MapStr{"id": "945254c5-67a5-417e-8a4e-aa29efcbfb79", "sampled": true},
-				"timestamp":   common.MapStr{"us": timestampUs},
-			},
-			Msg: "Payload with Event with Context.",
-		},
+	noHttp := event(nil, cfg).calcGroupingKey()
+	assert.Equal(t, event(nil, m.Config{}).calcGroupingKey(), noHttp)
+	assert.Equal(t, noHttp, event(&notFound, m.Config{}).calcGroupingKey())
+	assert.Equal(t, noHttp, (&Event{Exception: event(nil, cfg).Exception, Http: &m.Http{}, config: cfg}).calcGroupingKey())
+
+	clientError := event(&notFound, cfg).calcGroupingKey()
+	serverError := event(&internal, cfg).calcGroupingKey()
+	assert.NotEqual(t, noHttp, clientError)
+	assert.NotEqual(t, noHttp, serverError)
+	assert.NotEqual(t, clientError, serverError)
+	assert.Equal(t, clientError, event(&gone, cfg).calcGroupingKey())
+}
+
+func TestGroupingKeyIncludeModule(t *testing.T) {
+	event := func(module string, cfg m.Config) *Event {
+		ex := baseException().withType("type").withFrames([]*m.StacktraceFrame{{Filename: "file", Lineno: 1}})
+		if module != "" {
+			ex = ex.withModule(module)
+		}
+		return &Event{Exception: ex, config: cfg}
 	}
+	cfg := m.Config{GroupingKeyIncludeModule: true}
 
-	me := metadata.NewMetadata(&service, nil, nil, &metadata.User{Id: &uid}, metadataLabels)
-	tctx := &transform.Context{
-		Metadata:    *me,
-		Config:      transform.Config{SmapMapper: &sourcemap.SmapMapper{}},
-		RequestTime: timestamp,
+	assert.Equal(t, event("billing", m.Config{}).calcGroupingKey(), event("shipping", m.Config{}).calcGroupingKey())
+	assert.Equal(t, event("", m.Config{}).calcGroupingKey(), event("", cfg).calcGroupingKey())
+
+	billing := event("billing.invoices", cfg).calcGroupingKey()
+	shipping := event("shipping.labels", cfg).calcGroupingKey()
+	assert.NotEqual(t, billing, shipping)
+	assert.NotEqual(t, event("", cfg).calcGroupingKey(), billing)
+	assert.Equal(t, billing, event(" Billing/Invoices ", cfg).calcGroupingKey())
+	assert.Equal(t, billing, event("billing::invoices", cfg).calcGroupingKey())
+	assert.Equal(t, billing, event(`billing\invoices`, cfg).calcGroupingKey())
+
+	e := event("billing.invoices", m.Config{GroupingKeyIncludeModule: true, RecordGroupingComponents: true})
+	e.calcGroupingKey()
+	assert.Equal(t, "billing.invoices", e.groupingComponents[0])
+}
+
+func TestGroupingKeyNormalizeMessage(t *testing.T) {
+	event := func(msg string, cfg m.Config) *Event {
+		return &Event{Log: &Log{Message: msg}, config: cfg}
 	}
+	cfg := m.Config{GroupingKeyNormalizeMessage: true}
 
-	for idx, test := range tests {
-		outputEvents := test.Transformable.Transform(tctx)
-		require.Len(t, outputEvents, 1)
-		outputEvent := outputEvents[0]
-		assert.Equal(t, test.Output, outputEvent.Fields, fmt.Sprintf("Failed at idx %v; %s", idx, test.Msg))
-		assert.Equal(t, timestamp, outputEvent.Timestamp, fmt.Sprintf("Bad timestamp at idx %v; %s", idx, test.Msg))
+	assert.NotEqual(t, event("id 123 failed", m.Config{}).calcGroupingKey(), event("id 456 failed", m.Config{}).calcGroupingKey())
+	assert.Equal(t, event("id 123 failed", cfg).calcGroupingKey(), event("id 456 failed", cfg).calcGroupingKey())
+	assert.Equal(t,
+		event("request 6ba7b810-9dad-11d1-80b4-00c04fd430c8 at 0x1f2e timed out", cfg).calcGroupingKey(),
+		event("request 6ba7b811-9dad-11d1-80b4-00c04fd430c8 at 0xffff timed out", cfg).calcGroupingKey())
+	assert.Equal(t, event("commit deadbeef01 rejected", cfg).calcGroupingKey(), event("commit 0123abcdef rejected", cfg).calcGroupingKey())
+	assert.NotEqual(t, event("id 123 failed", cfg).calcGroupingKey(), event("id 123 succeeded", cfg).calcGroupingKey())
+
+	// the emitted message is not normalized
+	fields := event("id 123 failed", cfg).fields(&transform.Context{})
+	assert.Equal(t, "id 123 failed", fields["log"].(common.MapStr)["message"])
+
+	// exception messages are normalized, unless not grouped by
+	exception := func(msg string, exType *string) *Event {
+		return &Event{Exception: &Exception{Message: &msg, Type: exType}, config: cfg}
+	}
+	assert.Equal(t, exception("id 123 failed", nil).calcGroupingKey(), exception("id 456 failed", nil).calcGroupingKey())
+	exType := "type"
+	typed := exception("id 123 failed", &exType)
+	typed.config = m.Config{}
+	assert.Equal(t, typed.calcGroupingKey(), exception("id 123 failed", &exType).calcGroupingKey())
+
+	custom := m.Config{
+		GroupingKeyNormalizeMessage: true,
+		GroupingKeyMessageRules:     []m.RedactionRule{{Pattern: regexp.MustCompile(`user \w+`), Replacement: "user"}},
 	}
+	assert.Equal(t, event("user jane not found", custom).calcGroupingKey(), event("user joe not found", custom).calcGroupingKey())
+	assert.NotEqual(t, event("id 123 failed", custom).calcGroupingKey(), event("id 456 failed", custom).calcGroupingKey())
 }
 
-func TestCulprit(t *testing.T) {
-	c := "foo"
-	fct := "fct"
-	truthy := true
+func TestGroupingComponents(t *testing.T) {
+	fn, module, paramMsg := "handle", "app.handler", "user %s not found"
 	st := m.Stacktrace{
-		&m.StacktraceFrame{Filename: "a", Function: &fct, Sourcemap: m.Sourcemap{}},
+		&m.StacktraceFrame{Filename: "main.go", Lineno: 10, Function: &fn},
+		&m.StacktraceFrame{Filename: "handler.py", Lineno: 20, Module: &module},
+		&m.StacktraceFrame{Filename: "lib.go", Lineno: 30},
 	}
-	stUpdate := m.Stacktrace{
-		&m.StacktraceFrame{Filename: "a", Function: &fct, Sourcemap: m.Sourcemap{}},
-		&m.StacktraceFrame{Filename: "a", LibraryFrame: &truthy, Sourcemap: m.Sourcemap{Updated: &truthy}},
-		&m.StacktraceFrame{Filename: "f", Function: &fct, Sourcemap: m.Sourcemap{Updated: &truthy}},
-		&m.StacktraceFrame{Filename: "bar", Function: &fct, Sourcemap: m.Sourcemap{Updated: &truthy}},
+	event := func(cfg m.Config) *Event {
+		return &Event{
+			Exception: baseException().withType("DbError").withFrames(st),
+			Log:       baseLog().withParamMsg(paramMsg),
+			config:    cfg,
+		}
 	}
-	mapper := sourcemap.SmapMapper{}
-	tests := []struct {
-		event   Event
-		config  transform.Config
-		culprit string
-		msg     string
+
+	e := event(m.Config{})
+	e.calcGroupingKey()
+	assert.Nil(t, e.GroupingComponents())
+
+	e = event(m.Config{RecordGroupingComponents: true})
+	key := e.calcGroupingKey()
+	expected := []string{"DbError", paramMsg, "main.go", "handle", "app.handler", "20", "lib.go", "30"}
+	assert.Equal(t, expected, e.GroupingComponents())
+	assert.Equal(t, event(m.Config{}).calcGroupingKey(), key)
+	assert.Equal(t, hex.EncodeToString(md5With(expected...)), key)
+
+	service := "checkout"
+	e = event(m.Config{RecordGroupingComponents: true, GroupingKeyVersion: 2, GroupingKeyIgnoreLineno: true})
+	e.calcServiceGroupingKey(&service)
+	assert.Equal(t, []string{"v2/", service, "DbError", paramMsg, "main.go", "handle", "app.handler", "lib.go"}, e.GroupingComponents())
+
+	e = &Event{Log: &Log{Message: "message"}, config: m.Config{RecordGroupingComponents: true}}
+	e.calcGroupingKey()
+	assert.Equal(t, []string{"message"}, e.GroupingComponents())
+}
+
+func TestPreviewGroupingKey(t *testing.T) {
+	frames := []interface{}{
+		map[string]interface{}{"filename": "main.go", "lineno": json.Number("10"), "function": "handle"},
+		map[string]interface{}{"filename": "lib.go", "lineno": json.Number("30")},
+	}
+	for name, test := range map[string]struct {
+		input      map[string]interface{}
+		cfg        m.Config
+		key        string
+		components []string
+		err        string
 	}{
-		{
-			event:   Event{Culprit: &c},
-			config:  transform.Config{},
-			culprit: "foo",
-			msg:     "No Sourcemap in config",
+		"exception": {
+			input:      map[string]interface{}{"exception": map[string]interface{}{"type": "DbError", "message": "down", "stacktrace": frames}},
+			components: []string{"DbError", "main.go", "handle", "lib.go", "30"},
 		},
-		{
-			event:   Event{Culprit: &c},
-			config:  transform.Config{SmapMapper: &mapper},
-			culprit: "foo",
-			msg:     "No Stacktrace Frame given.",
+		"log": {
+			input:      map[string]interface{}{"log": map[string]interface{}{"message": "user 1 not found", "param_message": "user %s not found"}},
+			components: []string{"user %s not found"},
 		},
-		{
-			event:   Event{Culprit: &c, Log: &Log{Stacktrace: st}},
-			config:  transform.Config{SmapMapper: &mapper},
-			culprit: "foo",
-			msg:     "Log.StacktraceFrame has no updated frame",
+		"messageOnly": {
+			input:      map[string]interface{}{"log": map[string]interface{}{"message": "user 1 not found"}},
+			components: []string{"user 1 not found"},
 		},
-		{
-			event: Event{
-				Culprit: &c,
-				Log: &Log{
-					Stacktrace: m.Stacktrace{
-						&m.StacktraceFrame{
-							Filename:  "f",
-							Sourcemap: m.Sourcemap{Updated: &truthy},
-						},
-					},
-				},
+		"service": {
+			input: map[string]interface{}{
+				"exception": map[string]interface{}{"type": "DbError"},
+				"context":   map[string]interface{}{"service": map[string]interface{}{"name": "checkout"}},
 			},
-			config:  transform.Config{SmapMapper: &mapper},
-			culprit: "f",
-			msg:     "Adapt culprit to first valid Log.StacktraceFrame information.",
+			cfg:        m.Config{GroupingKeyIncludeService: true},
+			components: []string{"checkout", "DbError"},
 		},
-		{
-			event: Event{
-				Culprit:   &c,
-				Exception: &Exception{Stacktrace: stUpdate},
-			},
-			config:  transform.Config{SmapMapper: &mapper},
-			culprit: "f in fct",
-			msg:     "Adapt culprit to first valid Exception.StacktraceFrame information.",
+		"provided": {
+			input: map[string]interface{}{"grouping_key": "abc123", "log": map[string]interface{}{"message": "m"}},
+			key:   "abc123",
 		},
-		{
-			event: Event{
-				Culprit:   &c,
-				Log:       &Log{Stacktrace: st},
-				Exception: &Exception{Stacktrace: stUpdate},
-			},
-			config:  transform.Config{SmapMapper: &mapper},
-			culprit: "f in fct",
-			msg:     "Log and Exception StacktraceFrame given, only one changes culprit.",
+		"disabled": {
+			input: map[string]interface{}{"exception": map[string]interface{}{"type": "DbError"}},
+			cfg:   m.Config{DisableGroupingKey: true},
 		},
-		{
-			event: Event{
-				Culprit: &c,
-				Log: &Log{
-					Stacktrace: m.Stacktrace{
-						&m.StacktraceFrame{
-							Filename:  "a",
-							Function:  &fct,
-							Sourcemap: m.Sourcemap{Updated: &truthy},
-						},
-					},
+		"invalid": {
+			input: map[string]interface{}{"exception": map[string]interface{}{"type": 1}},
+			err:   "error.exception.type: expected string, got number",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			key, components, err := PreviewGroupingKey(test.input, test.cfg)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.components, components)
+			if test.components != nil {
+				assert.Equal(t, hex.EncodeToString(md5With(test.components...)), key)
+			} else {
+				assert.Equal(t, test.key, key)
+			}
+		})
+	}
+
+	// the previewed key is the one emitted for the error
+	input := map[string]interface{}{"exception": map[string]interface{}{"type": "DbError", "stacktrace": frames}}
+	key, _, err := PreviewGroupingKey(input, m.Config{})
+	require.NoError(t, err)
+	e, err := DecodeEvent(input, m.Config{}, nil)
+	require.NoError(t, err)
+	doc := e.(*Event).Document(nil)
+	assert.Equal(t, key, doc["error"].(common.MapStr)["grouping_key"])
+}
+
+func TestPreviewGroupingKeyWithoutSideEffects(t *testing.T) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	io.WriteString(w, `"o-42"`)
+	w.Close()
+	compressed := base64.StdEncoding.EncodeToString(buf.Bytes())
+
+	input := func() map[string]interface{} {
+		return map[string]interface{}{
+			"timestamp": json.Number("1496170422281000"),
+			"trace_id":  "invalid",
+			"context":   map[string]interface{}{"custom": map[string]interface{}{"order": map[string]interface{}{"$gzip": compressed}}},
+			"exception": map[string]interface{}{
+				"type":       "DbError",
+				"attributes": "invalid",
+				"stacktrace": []interface{}{
+					map[string]interface{}{"filename": "main.go", "lineno": json.Number("10")},
+					map[string]interface{}{"filename": "", "lineno": json.Number("20")},
+					map[string]interface{}{"filename": "lib.go", "lineno": json.Number("30")},
 				},
-				Exception: &Exception{Stacktrace: stUpdate},
 			},
-			config:  transform.Config{SmapMapper: &mapper},
-			culprit: "a in fct",
-			msg:     "Log Stacktrace is prioritized over Exception StacktraceFrame",
-		},
+		}
 	}
-	for idx, test := range tests {
-		tctx := &transform.Context{
-			Config: test.config,
+	cfg := m.Config{
+		CompressedValueKey:             "$gzip",
+		DropInvalidExceptionAttributes: true,
+		DropEmptyFrames:                true,
+		MaxStacktraceFrames:            1,
+		MaxTimestampPastSkew:           time.Hour,
+		TraceIdValidation:              "strict",
+	}
+	counters := func() monitoring.FlatSnapshot {
+		return monitoring.CollectFlatSnapshot(Metrics, monitoring.Full, false)
+	}
+
+	before := counters()
+	raw := input()
+	key, components, err := PreviewGroupingKey(raw, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"DbError", "main.go", "10"}, components)
+	assert.Equal(t, hex.EncodeToString(md5With(components...)), key)
+	_, _, err = PreviewGroupingKey(map[string]interface{}{"exception": map[string]interface{}{"type": 1}}, cfg)
+	require.Error(t, err)
+
+	assert.Equal(t, before, counters())
+	assert.Equal(t, input(), raw)
+}
+
+func TestGroupingMode(t *testing.T) {
+	fn := "handle"
+	st1 := m.Stacktrace{&m.StacktraceFrame{Filename: "main.go", Lineno: 10, Function: &fn}}
+	st2 := m.Stacktrace{&m.StacktraceFrame{Filename: "bundle.min.js", Lineno: 1}}
+	event := func(exType, msg string, st m.Stacktrace, mode string) *Event {
+		return &Event{
+			Exception: &Exception{Type: &exType, Message: &msg, Stacktrace: st},
+			Log:       baseLog().withParamMsg("param message"),
+			config:    m.Config{GroupingMode: mode, GroupingKeyNormalizeMessage: true, RecordGroupingComponents: true},
 		}
+	}
+
+	for _, mode := range []string{"", "stacktrace"} {
+		e := event("DbError", "id 123 failed", st1, mode)
+		e.calcGroupingKey()
+		assert.Equal(t, []string{"DbError", "param message", "main.go", "handle"}, e.GroupingComponents(), mode)
+		assert.NotEqual(t, e.calcGroupingKey(), event("DbError", "id 123 failed", st2, mode).calcGroupingKey(), mode)
+		assert.Equal(t, e.calcGroupingKey(), event("DbError", "id 456 lost", st1, mode).calcGroupingKey(), mode)
+	}
 
-		test.event.updateCulprit(tctx)
-		assert.Equal(t, test.culprit, *test.event.Culprit,
-			fmt.Sprintf("(%v) expected <%v>, received <%v>", idx, test.culprit, *test.event.Culprit))
+	e := event("DbError", "id 123 failed", st1, "message")
+	e.calcGroupingKey()
+	assert.Equal(t, []string{"id <num> failed"}, e.GroupingComponents())
+	assert.Equal(t, e.calcGroupingKey(), event("DbError", "id 456 failed", st2, "message").calcGroupingKey())
+	assert.Equal(t, e.calcGroupingKey(), event("IOError", "id 123 failed", st1, "message").calcGroupingKey())
+	assert.NotEqual(t, e.calcGroupingKey(), event("DbError", "id 123 lost", st1, "message").calcGroupingKey())
+
+	e = event("DbError", "id 123 failed", st1, "type_message")
+	e.calcGroupingKey()
+	assert.Equal(t, []string{"DbError", "id <num> failed"}, e.GroupingComponents())
+	assert.Equal(t, e.calcGroupingKey(), event("DbError", "id 456 failed", st2, "type_message").calcGroupingKey())
+	assert.NotEqual(t, e.calcGroupingKey(), event("IOError", "id 123 failed", st1, "type_message").calcGroupingKey())
+	assert.NotEqual(t, e.calcGroupingKey(), event("DbError", "id 123 lost", st1, "type_message").calcGroupingKey())
+
+	// message modes normalize the message regardless of GroupingKeyNormalizeMessage
+	for _, mode := range []string{"message", "type_message"} {
+		e = event("DbError", "id 123 failed", st1, mode)
+		e.config.GroupingKeyNormalizeMessage = false
+		e.calcGroupingKey()
+		assert.Contains(t, e.GroupingComponents(), "id <num> failed", mode)
+		other := event("DbError", "id 456 failed", st1, mode)
+		other.config.GroupingKeyNormalizeMessage = false
+		assert.Equal(t, e.calcGroupingKey(), other.calcGroupingKey(), mode)
 	}
+
+	// logs are grouped by their message
+	log := &Event{Log: &Log{Message: "log message", Stacktrace: st1}, config: m.Config{GroupingMode: "message"}}
+	assert.Equal(t, log.calcGroupingKey(), (&Event{Log: &Log{Message: "log message"}}).calcGroupingKey())
+
+	// frames are not collected while decoding in message modes
+	assert.Nil(t, newGroupingFrames(m.Config{IncrementalGroupingKey: true, GroupingMode: "message"}))
+	assert.NotNil(t, newGroupingFrames(m.Config{IncrementalGroupingKey: true, GroupingMode: "stacktrace"}))
 }
 
-func TestEmptyGroupingKey(t *testing.T) {
-	emptyGroupingKey := hex.EncodeToString(md5.New().Sum(nil))
-	e := Event{}
-	assert.Equal(t, emptyGroupingKey, e.calcGroupingKey())
+func TestDropEmptyFrames(t *testing.T) {
+	fn := "handle"
+	frames := []interface{}{
+		map[string]interface{}{"filename": "main.go", "lineno": 1.0},
+		map[string]interface{}{"filename": "", "lineno": 2.0},
+		map[string]interface{}{"filename": "", "function": fn, "lineno": 3.0},
+		map[string]interface{}{"filename": "", "function": "", "lineno": 4.0},
+		map[string]interface{}{"filename": "lib.go", "function": fn, "lineno": 5.0},
+	}
+	input := func() map[string]interface{} {
+		return map[string]interface{}{
+			"exception": map[string]interface{}{"type": "Error", "stacktrace": frames},
+			"log":       map[string]interface{}{"message": "log", "stacktrace": frames},
+		}
+	}
+	expected := m.Stacktrace{
+		{Filename: "main.go", Lineno: 1},
+		{Function: &fn, Lineno: 3},
+		{Filename: "lib.go", Function: &fn, Lineno: 5},
+	}
+
+	e, err := DecodeEvent(input(), m.Config{}, nil)
+	require.NoError(t, err)
+	assert.Len(t, e.(*Event).Exception.Stacktrace, 5)
+
+	before := emptyFramesDropped.Get()
+	e, err = DecodeEvent(input(), m.Config{DropEmptyFrames: true}, nil)
+	require.NoError(t, err)
+	event := e.(*Event)
+	assert.Equal(t, expected, event.Exception.Stacktrace)
+	assert.Equal(t, expected, event.Log.Stacktrace)
+	assert.Equal(t, before+4, emptyFramesDropped.Get())
+
+	// grouping keys computed while decoding match the ones of the kept frames
+	e, err = DecodeEvent(input(), m.Config{DropEmptyFrames: true, IncrementalGroupingKey: true}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, event.calcGroupingKey(), e.(*Event).calcGroupingKey())
 }
 
-func TestExplicitGroupingKey(t *testing.T) {
-	attr := "hello world"
-	diffAttr := "huhu"
+func TestCollapseRepeatedFrames(t *testing.T) {
+	fn, recurse := "main", "recurse"
+	main := &m.StacktraceFrame{Filename: "main.go", Lineno: 1, Function: &fn}
+	recursive := func(lineno int) *m.StacktraceFrame {
+		return &m.StacktraceFrame{Filename: "tree.go", Lineno: lineno, Function: &recurse}
+	}
+	stacktrace := func(depth int) m.Stacktrace {
+		st := m.Stacktrace{}
+		for i := 0; i < depth; i++ {
+			st = append(st, recursive(10))
+		}
+		return append(st, main)
+	}
+	event := func(st m.Stacktrace, cfg m.Config) *Event {
+		return &Event{Exception: baseException().withType("StackOverflow").withFrames(st), config: cfg}
+	}
 
-	groupingKey := hex.EncodeToString(md5With(attr))
+	// grouping keys
+	collapse := m.Config{GroupingKeyCollapseRepeatedFrames: true}
+	single := event(stacktrace(1), collapse).calcGroupingKey()
+	assert.Equal(t, single, event(stacktrace(500), collapse).calcGroupingKey())
+	assert.Equal(t, single, event(stacktrace(1), m.Config{}).calcGroupingKey())
+	assert.NotEqual(t, single, event(stacktrace(500), m.Config{}).calcGroupingKey())
+	// frames differing in values contributing to the key are kept
+	anonymous := func(lineno int) *m.StacktraceFrame { return &m.StacktraceFrame{Filename: "tree.go", Lineno: lineno} }
+	st := m.Stacktrace{anonymous(10), anonymous(11), anonymous(11)}
+	assert.NotEqual(t, event(m.Stacktrace{anonymous(10)}, collapse).calcGroupingKey(), event(st, collapse).calcGroupingKey())
+	assert.Equal(t, event(m.Stacktrace{anonymous(10), anonymous(11)}, collapse).calcGroupingKey(), event(st, collapse).calcGroupingKey())
+	// with line numbers ignored, frames only differing in their line are
+	// repeated frames
+	ignoreLineno := m.Config{GroupingKeyCollapseRepeatedFrames: true, GroupingKeyIgnoreLineno: true}
+	assert.Equal(t, event(m.Stacktrace{anonymous(10)}, ignoreLineno).calcGroupingKey(), event(st, ignoreLineno).calcGroupingKey())
 
-	e1 := Event{Log: baseLog().withParamMsg(attr)}
-	e2 := Event{Exception: baseException().withType(attr)}
-	e3 := Event{Log: baseLog().withFrames([]*m.StacktraceFrame{{Function: &attr}})}
-	e4 := Event{Exception: baseException().withFrames([]*m.StacktraceFrame{{Function: &attr}})}
-	e5 := Event{
-		Log:       baseLog().withFrames([]*m.StacktraceFrame{{Function: &diffAttr}}),
-		Exception: baseException().withFrames([]*m.StacktraceFrame{{Function: &attr}}),
+	// emitted frames
+	input := func(depth int) map[string]interface{} {
+		var frames []interface{}
+		for i := 0; i < depth; i++ {
+			frames = append(frames, map[string]interface{}{"filename": "tree.go", "lineno": 10.0, "function": recurse})
+		}
+		frames = append(frames, map[string]interface{}{"filename": "main.go", "lineno": 1.0, "function": fn})
+		return map[string]interface{}{
+			"exception": map[string]interface{}{"type": "StackOverflow", "stacktrace": frames},
+			"log":       map[string]interface{}{"message": "log", "stacktrace": frames},
+		}
 	}
+	e, err := DecodeEvent(input(500), m.Config{}, nil)
+	require.NoError(t, err)
+	assert.Len(t, e.(*Event).Exception.Stacktrace, 501)
 
-	for idx, e := range []Event{e1, e2, e3, e4, e5} {
-		assert.Equal(t, groupingKey, e.calcGroupingKey(), "grouping_key mismatch", idx)
+	for _, cfg := range []m.Config{
+		{CollapseRepeatedFrames: true},
+		{CollapseRepeatedFrames: true, IncrementalGroupingKey: true},
+		{CollapseRepeatedFrames: true, MaxStacktraceFrames: 2},
+	} {
+		e, err = DecodeEvent(input(500), cfg, nil)
+		require.NoError(t, err)
+		event := e.(*Event)
+		assert.Equal(t, m.Stacktrace{recursive(10), main}, event.Exception.Stacktrace)
+		assert.Equal(t, m.Stacktrace{recursive(10), main}, event.Log.Stacktrace)
+		assert.Equal(t, 0, event.Exception.framesTruncated)
+
+		e, err = DecodeEvent(input(1), cfg, nil)
+		require.NoError(t, err)
+		assert.Equal(t, e.(*Event).calcGroupingKey(), event.calcGroupingKey())
+	}
+}
+
+func TestIncrementalGroupingKey(t *testing.T) {
+	frame := func(filename string, lineno float64, extra map[string]interface{}) map[string]interface{} {
+		fr := map[string]interface{}{"filename": filename, "lineno": lineno}
+		for k, v := range extra {
+			fr[k] = v
+		}
+		return fr
+	}
+	stacktrace := []interface{}{
+		frame(`lib\util.go`, 1, map[string]interface{}{"library_frame": true}),
+		frame("main.go", 2, map[string]interface{}{"function": "main"}),
+		frame("handler.py", 3, map[string]interface{}{"module": "app.handler"}),
+		frame("other.go", 4, nil),
+	}
+	input := map[string]interface{}{
+		"exception": map[string]interface{}{
+			"type":       "OuterError",
+			"stacktrace": stacktrace,
+			"cause": []interface{}{
+				map[string]interface{}{"type": "RootError", "stacktrace": stacktrace[1:]},
+			},
+		},
+		"log": map[string]interface{}{"message": "log", "param_message": "failed %s", "stacktrace": stacktrace[2:]},
+	}
+	logOnly := map[string]interface{}{
+		"log": map[string]interface{}{"message": "log", "stacktrace": stacktrace},
+	}
+	emptyStacktrace := map[string]interface{}{
+		"exception": map[string]interface{}{"message": "ex", "stacktrace": []interface{}{}},
+		"log":       map[string]interface{}{"message": "log", "stacktrace": stacktrace},
+	}
+	for name, cfg := range map[string]m.Config{
+		"default":              {},
+		"ignoreLineno":         {GroupingKeyIgnoreLineno: true},
+		"excludeLibraryFrames": {GroupingKeyExcludeLibraryFrames: true},
+		"includeLibraryFlag":   {GroupingKeyIncludeLibraryFlag: true},
+		"normalizePaths":       {GroupingKeyNormalizePaths: true},
+		"preferRootCause":      {GroupingKeyPreferRootCause: true},
+		"maxStacktraceFrames":  {MaxStacktraceFrames: 2},
+		"versioned":            {GroupingKeyVersion: 2, GroupingKeyHash: "sha256"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			incremental := cfg
+			incremental.IncrementalGroupingKey = true
+			for _, input := range []map[string]interface{}{input, logOnly, emptyStacktrace} {
+				batch, err := DecodeEvent(input, cfg, nil)
+				require.NoError(t, err)
+				streamed, err := DecodeEvent(input, incremental, nil)
+				require.NoError(t, err)
+				require.NotNil(t, streamed.(*Event).Log.groupingFrames)
+				assert.Equal(t, batch.(*Event).calcGroupingKey(), streamed.(*Event).calcGroupingKey())
+
+				// the decoded frames are not needed anymore
+				e := streamed.(*Event)
+				e.Log.Stacktrace = nil
+				for _, ex := range e.exceptionChain() {
+					ex.Stacktrace = nil
+				}
+				assert.Equal(t, batch.(*Event).calcGroupingKey(), e.calcGroupingKey())
+			}
+		})
 	}
+
+	// frames changed when transforming are grouped as transformed
+	tctx := &transform.Context{Config: transform.Config{
+		LibraryPattern:      regexp.MustCompile("^lib"),
+		ExcludeFromGrouping: regexp.MustCompile("^other"),
+	}}
+	batch, err := DecodeEvent(input, m.Config{GroupingKeyExcludeLibraryFrames: true}, nil)
+	require.NoError(t, err)
+	streamed, err := DecodeEvent(input, m.Config{GroupingKeyExcludeLibraryFrames: true, IncrementalGroupingKey: true}, nil)
+	require.NoError(t, err)
+	batchDoc := batch.Transform(tctx)[0].Fields["error"].(common.MapStr)
+	streamedDoc := streamed.Transform(tctx)[0].Fields["error"].(common.MapStr)
+	assert.Equal(t, batchDoc["grouping_key"], streamedDoc["grouping_key"])
+	unpatterned, err := DecodeEvent(input, m.Config{GroupingKeyExcludeLibraryFrames: true, IncrementalGroupingKey: true}, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, unpatterned.(*Event).calcGroupingKey(), streamedDoc["grouping_key"])
+
+	e, err := DecodeEvent(input, m.Config{IncrementalGroupingKey: true, DisableGroupingKey: true}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, e.(*Event).Exception.groupingFrames)
 }
 
 func TestFramesUsableForGroupingKey(t *testing.T) {
@@ -738,7 +4187,7 @@ func TestFallbackGroupingKey(t *testing.T) {
 	lineno := 12
 	filename := "file"
 
-	groupingKey := hex.EncodeToString(md5With(filename, string(lineno)))
+	groupingKey := hex.EncodeToString(md5With(filename, strconv.Itoa(lineno)))
 
 	e := Event{Exception: baseException().withFrames([]*m.StacktraceFrame{{Lineno: lineno, Filename: filename}})}
 	assert.Equal(t, groupingKey, e.calcGroupingKey())
@@ -747,6 +4196,16 @@ func TestFallbackGroupingKey(t *testing.T) {
 	assert.Equal(t, groupingKey, e.calcGroupingKey())
 }
 
+func TestFallbackGroupingKeyLineno(t *testing.T) {
+	filename := "file"
+	e1 := Event{Exception: baseException().withFrames([]*m.StacktraceFrame{{Lineno: 10, Filename: filename}})}
+	e2 := Event{Exception: baseException().withFrames([]*m.StacktraceFrame{{Lineno: 49, Filename: filename}})}
+
+	assert.Equal(t, hex.EncodeToString(md5With(filename, "10")), e1.calcGroupingKey())
+	assert.Equal(t, hex.EncodeToString(md5With(filename, "49")), e2.calcGroupingKey())
+	assert.NotEqual(t, e1.calcGroupingKey(), e2.calcGroupingKey())
+}
+
 func TestNoFallbackGroupingKey(t *testing.T) {
 	lineno := 1
 	function := "function"
@@ -902,6 +4361,104 @@ func md5With(args ...string) []byte {
 	return md5.Sum(nil)
 }
 
+func TestStacktraceSourcemapApplied(t *testing.T) {
+	serviceName := "service"
+	colno := 1
+	frame := func(path string) *m.StacktraceFrame {
+		return &m.StacktraceFrame{Filename: path, AbsPath: &path, Lineno: 1, Colno: &colno}
+	}
+	tctx := func(mapper sourcemap.Mapper) *transform.Context {
+		return &transform.Context{
+			Config:   transform.Config{SmapMapper: mapper},
+			Metadata: metadata.Metadata{Service: &metadata.Service{Name: &serviceName}},
+		}
+	}
+	mapper := &pathMapper{paths: map[string]bool{"mapped.js": true}}
+
+	for name, test := range map[string]struct {
+		paths   []string
+		mapper  sourcemap.Mapper
+		applied interface{}
+	}{
+		"mapped":    {paths: []string{"mapped.js", "mapped.js"}, mapper: mapper, applied: true},
+		"partially": {paths: []string{"unmapped.js", "mapped.js"}, mapper: mapper, applied: true},
+		"unmapped":  {paths: []string{"unmapped.js"}, mapper: mapper, applied: false},
+		"empty":     {mapper: mapper},
+		"noMapper":  {paths: []string{"mapped.js"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var exFrames, logFrames m.Stacktrace
+			for _, path := range test.paths {
+				exFrames = append(exFrames, frame(path))
+				logFrames = append(logFrames, frame(path))
+			}
+			event := &Event{
+				Exception: baseException().withFrames(exFrames),
+				Log:       baseLog().withFrames(logFrames),
+			}
+			fields := event.fields(tctx(test.mapper))
+			exception := fields["exception"].([]common.MapStr)[0]
+			log := fields["log"].(common.MapStr)
+			for _, f := range []common.MapStr{exception, log} {
+				if test.applied == nil {
+					assert.NotContains(t, f, "stacktrace_sourcemap_applied")
+				} else {
+					assert.Equal(t, test.applied, f["stacktrace_sourcemap_applied"])
+				}
+			}
+		})
+	}
+}
+
+func TestStacktraceSourcemapErrorCount(t *testing.T) {
+	serviceName := "service"
+	colno := 1
+	frame := func(path string) *m.StacktraceFrame {
+		return &m.StacktraceFrame{Filename: path, AbsPath: &path, Lineno: 1, Colno: &colno}
+	}
+	stacktrace := func() m.Stacktrace {
+		st := m.Stacktrace{frame("mapped.js"), frame("unmapped.js"), frame("mapped.js"), frame("unmapped.js")}
+		// without colno, mapping the frame fails as well
+		st = append(st, &m.StacktraceFrame{Filename: "mapped.js", Lineno: 1})
+		return st
+	}
+	tctx := &transform.Context{
+		Config:   transform.Config{SmapMapper: &pathMapper{paths: map[string]bool{"mapped.js": true}}},
+		Metadata: metadata.Metadata{Service: &metadata.Service{Name: &serviceName}},
+	}
+
+	event := &Event{
+		Exception: baseException().withFrames(stacktrace()),
+		Log:       baseLog().withFrames(stacktrace()),
+	}
+	fields := event.fields(tctx)
+	assert.Equal(t, 3, fields["exception"].([]common.MapStr)[0]["stacktrace_sourcemap_error_count"])
+	assert.Equal(t, 3, fields["log"].(common.MapStr)["stacktrace_sourcemap_error_count"])
+
+	event = &Event{Exception: baseException().withFrames(m.Stacktrace{frame("mapped.js")})}
+	fields = event.fields(tctx)
+	assert.Equal(t, 0, fields["exception"].([]common.MapStr)[0]["stacktrace_sourcemap_error_count"])
+
+	// not set without sourcemapping
+	event = &Event{Exception: baseException().withFrames(stacktrace())}
+	fields = event.fields(&transform.Context{})
+	assert.NotContains(t, fields["exception"].([]common.MapStr)[0], "stacktrace_sourcemap_error_count")
+}
+
+// pathMapper maps the frames with one of the given paths onto themselves.
+type pathMapper struct {
+	paths map[string]bool
+}
+
+func (p *pathMapper) Apply(id sourcemap.Id, lineno, colno int) (*sourcemap.Mapping, error) {
+	if !p.paths[id.Path] {
+		return nil, sourcemap.Error{Msg: "no sourcemap found", Kind: sourcemap.KeyError}
+	}
+	return &sourcemap.Mapping{Filename: id.Path, Path: id.Path, Lineno: lineno, Colno: colno}, nil
+}
+
+func (p *pathMapper) NewSourcemapAdded(id sourcemap.Id) {}
+
 func TestSourcemapping(t *testing.T) {
 	c1 := 18
 	empty := ""
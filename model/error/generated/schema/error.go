@@ -22,16 +22,14 @@ const ModelSchema = `{
     "type": "object",
     "description": "An error or a logged error message captured by an agent occurring in a monitored service",
     "allOf": [
-        {     "$id": "doc/spec/timestamp_epoch.json",
-    "title": "Timestamp Epoch",
-    "description": "Object with 'timestamp' property.",
-    "type": ["object"],
-    "properties": {  
-        "timestamp": {
-            "description": "Recorded time of the event, UTC based and formatted as microseconds since Unix epoch",
-            "type": ["integer", "null"]
-        }
-    } },
+        {
+            "properties": {
+                "timestamp": {
+                    "description": "Recorded time of the event, UTC based and formatted as microseconds since Unix epoch. Depending on the server configuration, RFC3339 formatted strings and epoch seconds are accepted as well.",
+                    "type": ["number", "string", "null"]
+                }
+            }
+        },
         {  
             "properties": {
                 "id": {
@@ -54,13 +52,25 @@ const ModelSchema = `{
                     "type": ["string", "null"],
                     "maxLength": 1024
                 },
+                "parent": {
+                    "description": "Parent transaction or span of the error, as sent by newer agents instead of parent_id. Takes precedence over parent_id.",
+                    "type": ["object", "null"],
+                    "properties": {
+                        "id": {
+                            "description": "Hex encoded 64 random bits ID of the parent transaction or span.",
+                            "type": ["string", "null"],
+                            "maxLength": 1024
+                        }
+                    }
+                },
                 "transaction": {
                     "type": ["object", "null"],
                     "description": "Data for correlating errors with transactions",
                     "properties": {
                         "sampled": {
-                            "type": ["boolean", "null"],
-                            "description": "Transactions that are 'sampled' will include all available information. Transactions that are not sampled will not have 'spans' or 'context'. Defaults to true."
+                            "type": ["boolean", "string", "null"],
+                            "enum": [true, false, "true", "false", "1", "0", null],
+                            "description": "Transactions that are 'sampled' will include all available information. Transactions that are not sampled will not have 'spans' or 'context'. Defaults to true. String values are only accepted if the server is configured to decode booleans leniently."
                         },
                         "type": {
                             "type": ["string", "null"],
@@ -352,14 +362,31 @@ const ModelSchema = `{
                     "type": ["string", "null"],
                     "maxLength": 1024
                 },
+                "grouping_key": {
+                    "description": "Hex encoded key for grouping this error with similar errors. If set, it is used instead of the grouping key computed by the APM Server.",
+                    "type": ["string", "null"],
+                    "pattern": "^[0-9a-fA-F]+$",
+                    "maxLength": 1024
+                },
                 "exception": {
                     "description": "Information about the originally thrown error.",
                     "type": ["object", "null"],
                     "properties": {
                         "code": {
-                            "type": ["string", "integer", "null"],
+                            "type": ["string", "integer", "object", "null"],
                             "maxLength": 1024,
-                            "description": "The error code set when the error happened, e.g. database error code."
+                            "description": "The error code set when the error happened, e.g. database error code. Structured codes are given as object holding the code value and its category.",
+                            "properties": {
+                                "value": {
+                                    "type": ["string", "integer"],
+                                    "maxLength": 1024
+                                },
+                                "category": {
+                                    "type": ["string", "null"],
+                                    "maxLength": 1024
+                                }
+                            },
+                            "required": ["value"]
                         },
                         "message": {
                             "description": "The original error message.",
@@ -371,10 +398,11 @@ const ModelSchema = `{
                             "maxLength": 1024
                         },
                         "attributes": {
-                            "type": ["object", "null"]
+                            "description": "Arbitrary attributes of the exception, given as object. Other values are only accepted if the server is configured to drop them."
                         },
                         "stacktrace": {
-                            "type": ["array", "null"],
+                            "type": ["array", "object", "null"],
+                            "description": "The stacktrace frames, or an object holding the frames and the number of frames omitted by the agent.",
                             "items": {
                                     "$id": "docs/spec/stacktrace_frame.json",
     "title": "Stacktrace",
@@ -437,20 +465,108 @@ const ModelSchema = `{
     },
     "required": ["filename", "lineno"]
                             },
-                            "minItems": 0
+                            "minItems": 0,
+                            "properties": {
+                                "frames": {
+                                    "type": ["array", "null"],
+                                    "items": {
+                                            "$id": "docs/spec/stacktrace_frame.json",
+    "title": "Stacktrace",
+    "type": "object",
+    "description": "A stacktrace frame, contains various bits (most optional) describing the context of the frame",
+    "properties": {
+        "abs_path": {
+            "description": "The absolute path of the file involved in the stack frame",
+            "type": ["string", "null"]
+        },
+        "colno": {
+            "description": "Column number",
+            "type": ["integer", "null"]
+        },
+        "context_line": {
+            "description": "The line of code part of the stack frame",
+            "type": ["string", "null"]
+        },
+        "filename": {
+            "description": "The relative filename of the code involved in the stack frame, used e.g. to do error checksumming",
+            "type": "string"
+        },
+        "function": {
+            "description": "The function involved in the stack frame",
+            "type": ["string", "null"]
+        },
+        "library_frame": {
+            "description": "A boolean, indicating if this frame is from a library or user code",
+            "type": ["boolean", "null"]
+        },
+        "lineno": {
+            "description": "The line number of code part of the stack frame, used e.g. to do error checksumming",
+            "type": "integer"
+        },
+        "module": {
+            "description": "The module to which frame belongs to",
+            "type": ["string", "null"]
+        },
+        "post_context": {
+            "description": "The lines of code after the stack frame",
+            "type": ["array", "null"],
+            "minItems": 0,
+            "items": {
+                "type": "string"
+            }
+        },
+        "pre_context": {
+            "description": "The lines of code before the stack frame",
+            "type": ["array", "null"],
+            "minItems": 0,
+            "items": {
+                "type": "string"
+            }
+        },
+        "vars": {
+            "description": "Local variables for this stack frame",
+            "type": ["object", "null"],
+            "properties": {}
+        }
+    },
+    "required": ["filename", "lineno"]
+                                    },
+                                    "minItems": 0
+                                },
+                                "frames_omitted": {
+                                    "description": "The number of frames omitted by the agent, e.g. when exceeding its frame limit.",
+                                    "type": ["integer", "null"],
+                                    "minimum": 0
+                                }
+                            }
                         },
                         "type": {
-                            "type": ["string", "null"],
-                            "maxLength": 1024
+                            "type": ["string", "array", "null"],
+                            "description": "The type of the exception. Languages with multiple exception classes, e.g. in Python's method resolution order, may send an array starting with the most specific class.",
+                            "maxLength": 1024,
+                            "items": {
+                                "type": "string",
+                                "maxLength": 1024
+                            },
+                            "minItems": 1
                         },
                         "handled": {
-                            "type": ["boolean", "null"],
-                            "description": "Indicator whether the error was caught somewhere in the code or not."
+                            "type": ["boolean", "string", "null"],
+                            "enum": [true, false, "true", "false", "1", "0", null],
+                            "description": "Indicator whether the error was caught somewhere in the code or not. String values are only accepted if the server is configured to decode booleans leniently."
+                        },
+                        "cause": {
+                            "type": ["array", "null"],
+                            "description": "Exceptions that caused this exception, each holding the same properties as the exception itself.",
+                            "items": {
+                                "$ref": "#/allOf/1/properties/exception"
+                            },
+                            "minItems": 0
                         }
                     },
                     "anyOf": [
                         {"required": ["message"], "properties": {"message": {"type": "string"}}},
-                        {"required": ["type"], "properties": {"type": {"type": "string"}}}
+                        {"required": ["type"], "properties": {"type": {"type": ["string", "array"]}}}
                     ]
                 },
                 "log": {
@@ -479,7 +595,8 @@ const ModelSchema = `{
 
                         },
                         "stacktrace": {
-                            "type": ["array", "null"],
+                            "type": ["array", "object", "null"],
+                            "description": "The stacktrace frames, or an object holding the frames and the number of frames omitted by the agent.",
                             "items": {
                                     "$id": "docs/spec/stacktrace_frame.json",
     "title": "Stacktrace",
@@ -542,7 +659,80 @@ const ModelSchema = `{
     },
     "required": ["filename", "lineno"]
                             },
-                            "minItems": 0
+                            "minItems": 0,
+                            "properties": {
+                                "frames": {
+                                    "type": ["array", "null"],
+                                    "items": {
+                                            "$id": "docs/spec/stacktrace_frame.json",
+    "title": "Stacktrace",
+    "type": "object",
+    "description": "A stacktrace frame, contains various bits (most optional) describing the context of the frame",
+    "properties": {
+        "abs_path": {
+            "description": "The absolute path of the file involved in the stack frame",
+            "type": ["string", "null"]
+        },
+        "colno": {
+            "description": "Column number",
+            "type": ["integer", "null"]
+        },
+        "context_line": {
+            "description": "The line of code part of the stack frame",
+            "type": ["string", "null"]
+        },
+        "filename": {
+            "description": "The relative filename of the code involved in the stack frame, used e.g. to do error checksumming",
+            "type": "string"
+        },
+        "function": {
+            "description": "The function involved in the stack frame",
+            "type": ["string", "null"]
+        },
+        "library_frame": {
+            "description": "A boolean, indicating if this frame is from a library or user code",
+            "type": ["boolean", "null"]
+        },
+        "lineno": {
+            "description": "The line number of code part of the stack frame, used e.g. to do error checksumming",
+            "type": "integer"
+        },
+        "module": {
+            "description": "The module to which frame belongs to",
+            "type": ["string", "null"]
+        },
+        "post_context": {
+            "description": "The lines of code after the stack frame",
+            "type": ["array", "null"],
+            "minItems": 0,
+            "items": {
+                "type": "string"
+            }
+        },
+        "pre_context": {
+            "description": "The lines of code before the stack frame",
+            "type": ["array", "null"],
+            "minItems": 0,
+            "items": {
+                "type": "string"
+            }
+        },
+        "vars": {
+            "description": "Local variables for this stack frame",
+            "type": ["object", "null"],
+            "properties": {}
+        }
+    },
+    "required": ["filename", "lineno"]
+                                    },
+                                    "minItems": 0
+                                },
+                                "frames_omitted": {
+                                    "description": "The number of frames omitted by the agent, e.g. when exceeding its frame limit.",
+                                    "type": ["integer", "null"],
+                                    "minimum": 0
+                                }
+                            }
                         }
                     },
                     "required": ["message"]
@@ -551,10 +741,13 @@ const ModelSchema = `{
             "allOf": [
                 { "required": ["id"] },
                 { "if": {"required": ["transaction_id"], "properties": {"transaction_id": { "type": "string" }}},
-                    "then": { "required": ["trace_id", "parent_id"], "properties": {"trace_id": { "type": "string" }, "parent_id": {"type": "string"}}}},
-                { "if": {"required": ["trace_id"], "properties": {"trace_id": { "type": "string" }}},
-                    "then": { "required": ["parent_id"], "properties": {"parent_id": { "type": "string" }}} },
-                { "if": {"required": ["parent_id"], "properties": {"parent_id": { "type": "string" }}},
+                    "then": { "required": ["trace_id"], "properties": {"trace_id": { "type": "string" }}}},
+                { "if": {"anyOf": [{"required": ["transaction_id"], "properties": {"transaction_id": { "type": "string" }}},
+                                   {"required": ["trace_id"], "properties": {"trace_id": { "type": "string" }}}]},
+                    "then": { "anyOf": [{"required": ["parent_id"], "properties": {"parent_id": { "type": "string" }}},
+                                        {"required": ["parent"], "properties": {"parent": {"type": "object", "required": ["id"], "properties": {"id": { "type": "string" }}}}}]} },
+                { "if": {"anyOf": [{"required": ["parent_id"], "properties": {"parent_id": { "type": "string" }}},
+                                   {"required": ["parent"], "properties": {"parent": {"type": "object", "required": ["id"], "properties": {"id": { "type": "string" }}}}}]},
                     "then": { "required": ["trace_id"], "properties": {"trace_id": { "type": "string" }}} }
             ],
             "anyOf": [
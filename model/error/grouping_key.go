@@ -0,0 +1,512 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package error
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	m "github.com/elastic/apm-server/model"
+	"github.com/elastic/apm-server/transform"
+)
+
+func (e *Event) addGroupingKey(tctx *transform.Context) {
+	key, source := e.groupingKey(tctx)
+	if key == "" {
+		// nothing to group the error by
+		return
+	}
+	e.add("grouping_key", key)
+	e.add("grouping_key_source", source)
+	if e.config.GroupingKeyCardinalityWindow > 0 {
+		groupingKeys.add(key, time.Now(), e.config.GroupingKeyCardinalityWindow)
+	}
+	if source == "computed" && e.config.GroupingKeyVersion > 0 {
+		e.add("grouping_key_version", e.config.GroupingKeyVersion)
+	}
+}
+
+// groupingKey returns the grouping key of the error and whether it was
+// "provided" by the agent or "computed".
+func (e *Event) groupingKey(tctx *transform.Context) (string, string) {
+	// a grouping key sent by the agent takes precedence over a computed one
+	if e.GroupingKey != nil {
+		return *e.GroupingKey, "provided"
+	}
+	if transformsFrames(tctx) {
+		// frames collected while decoding miss the changes made when
+		// transforming them, so the key is computed from the transformed
+		// frames instead
+		e.dropGroupingFrames()
+	}
+	var service *string
+	if e.config.GroupingKeyIncludeService {
+		service = e.serviceName(tctx)
+	}
+	return e.calcServiceGroupingKey(service), "computed"
+}
+
+// serviceName returns the name of the service the error occurred in, as
+// sent with the event or else with the metadata.
+func (e *Event) serviceName(tctx *transform.Context) *string {
+	if e.Service != nil && e.Service.Name != nil {
+		return e.Service.Name
+	}
+	if tctx.Metadata.Service != nil {
+		return tctx.Metadata.Service.Name
+	}
+	return nil
+}
+
+type groupingKey struct {
+	algorithm string
+	hash      hash.Hash
+	sum       []byte
+	empty     bool
+
+	// components records the values written to the hash if not nil
+	components []string
+}
+
+// groupingKeyPools hold grouping keys per hash algorithm, so hashers are
+// reused rather than allocated per event.
+var groupingKeyPools = map[string]*sync.Pool{
+	"md5":    {New: func() interface{} { return &groupingKey{algorithm: "md5", hash: md5.New()} }},
+	"sha1":   {New: func() interface{} { return &groupingKey{algorithm: "sha1", hash: sha1.New()} }},
+	"sha256": {New: func() interface{} { return &groupingKey{algorithm: "sha256", hash: sha256.New()} }},
+}
+
+// newGroupingKey returns an empty grouping key hashed with the given
+// algorithm, md5 by default. Unknown algorithms are rejected when the
+// configuration is loaded, see m.Config.Validate. The key should be released
+// once done with.
+func newGroupingKey(algorithm string) *groupingKey {
+	pool, ok := groupingKeyPools[algorithm]
+	if !ok {
+		pool = groupingKeyPools["md5"]
+	}
+	k := pool.Get().(*groupingKey)
+	k.hash.Reset()
+	k.empty = true
+	return k
+}
+
+// release returns the key to its pool. It must not be used afterwards.
+func (k *groupingKey) release() {
+	k.components = nil
+	groupingKeyPools[k.algorithm].Put(k)
+}
+
+func (k *groupingKey) add(s *string) bool {
+	if s == nil {
+		return false
+	}
+	k.write(*s)
+	k.empty = false
+	return true
+}
+
+// write writes s to the hash without counting it as content.
+func (k *groupingKey) write(s string) {
+	io.WriteString(k.hash, s)
+	if k.components != nil {
+		k.components = append(k.components, s)
+	}
+}
+
+func (k *groupingKey) addEither(s1 *string, s2 string) {
+	if ok := k.add(s1); !ok {
+		k.add(&s2)
+	}
+}
+
+// String returns the hex encoded hash, or an empty string if no content was
+// added to the key.
+func (k *groupingKey) String() string {
+	if k.empty {
+		return ""
+	}
+	k.sum = k.hash.Sum(k.sum[:0])
+	return hex.EncodeToString(k.sum)
+}
+
+// Base64 returns the base64 encoded hash, or an empty string if no content
+// was added to the key.
+func (k *groupingKey) Base64() string {
+	if k.empty {
+		return ""
+	}
+	k.sum = k.hash.Sum(k.sum[:0])
+	return base64.StdEncoding.EncodeToString(k.sum)
+}
+
+// encode returns the hash in the given encoding, hex by default.
+func (k *groupingKey) encode(encoding string) string {
+	if encoding == "base64" {
+		return k.Base64()
+	}
+	return k.String()
+}
+
+// calcGroupingKey computes a value for deduplicating errors - events with
+// same grouping key can be collapsed together.
+func (e *Event) calcGroupingKey() string {
+	return e.calcServiceGroupingKey(nil)
+}
+
+// calcServiceGroupingKey computes the grouping key, prefixed with the
+// given service name if not nil and, if configured, the HTTP status class.
+func (e *Event) calcServiceGroupingKey(service *string) string {
+	scopes := []*string{service}
+	if e.config.GroupingKeyIncludeStatusClass {
+		scopes = append(scopes, statusClass(e.Http))
+	}
+	chain := e.exceptionChain()
+	if e.config.GroupingKeyPreferRootCause && len(chain) > 0 {
+		chain = chain[len(chain)-1:]
+	}
+	if e.config.GroupingKeyIncludeModule && len(chain) > 0 {
+		scopes = append(scopes, normalizeModule(chain[0].Module))
+	}
+	var exceptionTypes []*string
+	var st m.Stacktrace
+	var frameValues []string
+	var frames int
+	for _, ex := range chain {
+		exceptionTypes = append(exceptionTypes, ex.typeName(e.config))
+		if ex.groupingFrames != nil {
+			frameValues = append(frameValues, ex.groupingFrames.values...)
+			frames += ex.groupingFrames.frames
+		} else {
+			st = append(st, ex.Stacktrace...)
+			frames += len(ex.Stacktrace)
+		}
+	}
+
+	var paramMessage, message *string
+	if len(chain) > 0 {
+		message = chain[0].Message
+	} else if e.Log != nil {
+		message = &e.Log.Message
+	}
+	if e.Log != nil {
+		paramMessage = e.Log.ParamMessage
+		if frames == 0 {
+			if e.Log.groupingFrames != nil {
+				frameValues = e.Log.groupingFrames.values
+			} else {
+				st = e.Log.Stacktrace
+			}
+		}
+	}
+	k := newGroupingKey(e.config.GroupingKeyHash)
+	defer k.release()
+	if e.config.RecordGroupingComponents {
+		k.components = []string{}
+	}
+	k.compute(e.config, scopes, exceptionTypes, paramMessage, message, st, frameValues)
+	e.groupingComponents = k.components
+	return k.encode(e.config.GroupingKeyEncoding)
+}
+
+// GroupingComponents returns the values fed into the hash when the grouping
+// key of the error was last computed, in order. Values are only recorded if
+// configured with RecordGroupingComponents.
+func (e *Event) GroupingComponents() []string {
+	return e.groupingComponents
+}
+
+// PreviewGroupingKey decodes an error payload and returns the grouping key it
+// would be emitted with, together with the values the key is computed from,
+// without transforming the error. A grouping key sent with the error is
+// returned as is, without components. Decoding errors are returned as
+// DecodeEvent returns them. Previewing has no side effects: the input is not
+// modified, monitoring counters are not updated, and checks not affecting
+// the grouping key, like those of trace ids and timestamps, are skipped.
+// An invalid configuration is returned as error.
+func PreviewGroupingKey(input map[string]interface{}, cfg m.Config) (string, []string, error) {
+	if cfg.DisableGroupingKey {
+		return "", nil, nil
+	}
+	if err := cfg.Validate(); err != nil {
+		return "", nil, err
+	}
+	cfg.RecordGroupingComponents = true
+	// compressed values are decompressed in place and never grouped by
+	cfg.CompressedValueKey = ""
+	cfg.TimestampFallback = true
+	cfg.ClampTimestamps = false
+	cfg.MaxTimestampPastSkew, cfg.MaxTimestampFutureSkew = 0, 0
+	cfg.RequireTraceId = false
+	cfg.TraceIdValidation = ""
+	var e Event
+	if err := decodeEvent(&e, input, cfg, &decodeStats{}); err != nil {
+		return "", nil, err
+	}
+	key, _ := e.groupingKey(&transform.Context{})
+	return key, e.groupingComponents, nil
+}
+
+// statusClass returns the class of the HTTP response status code, e.g. "5xx"
+// for 503, or nil if there is no status code.
+func statusClass(h *m.Http) *string {
+	if h == nil || h.Response == nil || h.Response.StatusCode == nil {
+		return nil
+	}
+	class := fmt.Sprintf("%dxx", *h.Response.StatusCode/100)
+	return &class
+}
+
+var moduleSeparators = strings.NewReplacer("::", ".", "/", ".", `\`, ".")
+
+// normalizeModule returns the module in lower case with its separators
+// unified to ".", or nil if there is no module.
+func normalizeModule(module *string) *string {
+	if module == nil {
+		return nil
+	}
+	normalized := moduleSeparators.Replace(strings.ToLower(strings.TrimSpace(*module)))
+	if normalized == "" {
+		return nil
+	}
+	return &normalized
+}
+
+// GroupingKey computes the grouping key of an error from its exception type,
+// log param_message, message and stacktrace, as used for error.grouping_key.
+// The stacktrace is the exception's one, or the log's one if the exception has
+// no frames. The message is only taken into account if none of the other
+// values contribute to the key. Without any of the values, the key is empty.
+func GroupingKey(cfg m.Config, exceptionType, paramMessage, message *string, st m.Stacktrace) string {
+	return computeGroupingKey(cfg, nil, []*string{exceptionType}, paramMessage, message, st, nil)
+}
+
+// computeGroupingKey computes the grouping key from the given values. Scopes,
+// e.g. the service name, are mixed into the key, but do not count as
+// content: without content, the message is used. Frame values collected
+// while decoding are added after the frames of st. If neither content nor a
+// message is given, the key is empty, rather than the same hash for all such
+// errors.
+func computeGroupingKey(cfg m.Config, scopes []*string, exceptionTypes []*string, paramMessage, message *string, st m.Stacktrace, frameValues []string) string {
+	k := newGroupingKey(cfg.GroupingKeyHash)
+	defer k.release()
+	k.compute(cfg, scopes, exceptionTypes, paramMessage, message, st, frameValues)
+	return k.encode(cfg.GroupingKeyEncoding)
+}
+
+// compute adds the given values to the key, as described for
+// computeGroupingKey.
+func (k *groupingKey) compute(cfg m.Config, scopes []*string, exceptionTypes []*string, paramMessage, message *string, st m.Stacktrace, frameValues []string) {
+	if cfg.GroupingKeyVersion > 1 {
+		// the version is the outermost scope of the key
+		k.write("v" + strconv.Itoa(cfg.GroupingKeyVersion) + "/")
+	}
+	for _, scope := range scopes {
+		if scope != nil {
+			k.write(*scope)
+		}
+	}
+	if !groupsByStacktrace(cfg) {
+		if cfg.GroupingMode == "type_message" {
+			for _, exType := range exceptionTypes {
+				k.add(exType)
+			}
+		}
+		if message != nil {
+			// message modes always normalize the message, as grouping by the
+			// raw message would split errors by the values in it
+			normalized := cfg.NormalizeMessage(*message)
+			k.add(&normalized)
+		}
+		return
+	}
+	for _, exType := range exceptionTypes {
+		k.add(exType)
+	}
+	k.add(paramMessage)
+
+	var prev []string
+	for _, fr := range st {
+		values := groupingValues(cfg, fr)
+		if cfg.GroupingKeyCollapseRepeatedFrames && len(values) > 0 && equalStrings(values, prev) {
+			continue
+		}
+		for i := range values {
+			k.add(&values[i])
+		}
+		if len(values) > 0 {
+			prev = values
+		}
+	}
+	for i := range frameValues {
+		k.add(&frameValues[i])
+	}
+	if k.empty && message != nil {
+		normalized := cfg.NormalizeGroupingMessage(*message)
+		k.add(&normalized)
+	}
+}
+
+// groupsByStacktrace reports whether stacktrace frames contribute to grouping
+// keys in the configured grouping mode.
+func groupsByStacktrace(cfg m.Config) bool {
+	return cfg.GroupingMode != "message" && cfg.GroupingMode != "type_message"
+}
+
+// addFrameValues passes the values of a stacktrace frame contributing to the
+// grouping key to add, in the order they are hashed.
+func addFrameValues(cfg m.Config, fr *m.StacktraceFrame, add func(string)) {
+	if fr.ExcludeFromGrouping || (cfg.GroupingKeyExcludeLibraryFrames && fr.IsLibraryFrame()) {
+		return
+	}
+	if fr.Module != nil {
+		add(*fr.Module)
+	} else {
+		add(groupingFilename(cfg, fr.Filename))
+	}
+	if fr.Function != nil {
+		add(*fr.Function)
+	} else if !cfg.GroupingKeyIgnoreLineno {
+		add(strconv.Itoa(fr.Lineno))
+	}
+	if cfg.GroupingKeyIncludeLibraryFlag {
+		if fr.IsLibraryFrame() {
+			add(libraryFrameToken)
+		} else {
+			add(appFrameToken)
+		}
+	}
+}
+
+// groupingFilename returns the filename of a stacktrace frame as hashed for
+// grouping keys, normalized as configured.
+func groupingFilename(cfg m.Config, filename string) string {
+	if cfg.GroupingKeyNormalizePaths || cfg.GroupingKeyFilenameBasename {
+		filename = strings.Replace(filename, `\`, "/", -1)
+	}
+	if cfg.GroupingKeyFilenameBasename && filename != "" {
+		filename = path.Base(filename)
+	}
+	return filename
+}
+
+// groupingValues returns the values of a stacktrace frame contributing to the
+// grouping key, in the order they are hashed.
+func groupingValues(cfg m.Config, fr *m.StacktraceFrame) []string {
+	var values []string
+	addFrameValues(cfg, fr, func(s string) { values = append(values, s) })
+	return values
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// transformsFrames reports whether stacktrace frames are changed when
+// transforming them, by sourcemaps or library frame and exclude_from_grouping
+// patterns.
+func transformsFrames(tctx *transform.Context) bool {
+	if tctx == nil {
+		return false
+	}
+	c := tctx.Config
+	return c.LibraryPattern != nil || c.ExcludeFromGrouping != nil || c.SmapMapper != nil
+}
+
+// dropGroupingFrames discards the frame values collected while decoding, so
+// the grouping key is computed from the stacktraces.
+func (e *Event) dropGroupingFrames() {
+	for _, ex := range e.exceptionChain() {
+		ex.groupingFrames = nil
+	}
+	if e.Log != nil {
+		e.Log.groupingFrames = nil
+	}
+}
+
+// groupingFrames holds the values of a stacktrace contributing to the
+// grouping key, collected frame by frame while decoding.
+type groupingFrames struct {
+	cfg    m.Config
+	frames int
+	values []string
+
+	// last frame visited and last values collected, for collapsing
+	// repeated frames
+	last *m.StacktraceFrame
+	prev []string
+}
+
+// newGroupingFrames returns nil unless the grouping key is configured to be
+// computed incrementally.
+func newGroupingFrames(cfg m.Config) *groupingFrames {
+	if !cfg.IncrementalGroupingKey || cfg.DisableGroupingKey || !groupsByStacktrace(cfg) {
+		return nil
+	}
+	return &groupingFrames{cfg: cfg}
+}
+
+// visitor returns the function collecting the values of decoded frames, or
+// nil if g is nil. Frames beyond the configured maximum are not collected,
+// as they are truncated from the stacktrace. Repeated frames are skipped as
+// configured for collapsing them.
+func (g *groupingFrames) visitor() func(*m.StacktraceFrame) {
+	if g == nil {
+		return nil
+	}
+	return func(fr *m.StacktraceFrame) {
+		if g.cfg.DropEmptyFrames && isEmptyFrame(fr) {
+			return
+		}
+		if g.cfg.CollapseRepeatedFrames && g.last != nil && sameFrame(g.last, fr) {
+			return
+		}
+		g.last = fr
+		if g.cfg.MaxStacktraceFrames > 0 && g.frames >= g.cfg.MaxStacktraceFrames {
+			return
+		}
+		g.frames++
+		values := groupingValues(g.cfg, fr)
+		if g.cfg.GroupingKeyCollapseRepeatedFrames && len(values) > 0 && equalStrings(values, g.prev) {
+			return
+		}
+		g.values = append(g.values, values...)
+		if len(values) > 0 {
+			g.prev = values
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package error
+
+import (
+	"sync"
+
+	"github.com/elastic/apm-server/transform"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// maxSampledGroupingKeys bounds the number of grouping keys errors are
+// counted for when sampling
+const maxSampledGroupingKeys = 10000
+
+// sample reports whether the error of the given document is kept at the
+// configured sample rate. Errors without any content to group by are always
+// kept, as unrelated errors would otherwise be sampled against each other.
+func (e *Event) sample(tctx *transform.Context, fields common.MapStr) bool {
+	errorFields, _ := fields["error"].(common.MapStr)
+	key, _ := errorFields["grouping_key"].(string)
+	if key == "" && e.config.DisableGroupingKey {
+		key, _ = e.groupingKey(tctx)
+	}
+	if key == "" {
+		return true
+	}
+	return sampler.keep(key, e.config.SampleRate)
+}
+
+// groupingKeySampler keeps the first and then every rate-th error per
+// grouping key. Once counts are held for max keys, counting starts over.
+type groupingKeySampler struct {
+	mu     sync.Mutex
+	max    int
+	counts map[string]int
+}
+
+func newGroupingKeySampler(max int) *groupingKeySampler {
+	return &groupingKeySampler{max: max, counts: make(map[string]int)}
+}
+
+// keep counts an error with the given grouping key and reports whether it
+// is to be kept at the given rate.
+func (s *groupingKeySampler) keep(key string, rate int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	count, ok := s.counts[key]
+	if !ok && len(s.counts) >= s.max {
+		s.counts = make(map[string]int)
+	}
+	s.counts[key] = count + 1
+	return count%rate == 0
+}
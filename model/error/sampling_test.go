@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package error
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	m "github.com/elastic/apm-server/model"
+	"github.com/elastic/apm-server/transform"
+)
+
+func TestSampling(t *testing.T) {
+	transformAll := func(keys []string, perKey int, cfg m.Config) map[string]int {
+		kept := make(map[string]int)
+		for i := 0; i < perKey; i++ {
+			for _, key := range keys {
+				key := key
+				e := Event{GroupingKey: &key, Log: baseLog(), config: cfg}
+				events := e.Transform(&transform.Context{})
+				kept[key] += len(events)
+			}
+		}
+		return kept
+	}
+
+	before := sampledOut.Get()
+	kept := transformAll([]string{"5a01", "5a02", "5a03"}, 95, m.Config{SampleRate: 10})
+	assert.Equal(t, map[string]int{"5a01": 10, "5a02": 10, "5a03": 10}, kept)
+	assert.Equal(t, int64(3*95-30), sampledOut.Get()-before)
+
+	// sampling is per grouping key, a new key is kept right away
+	kept = transformAll([]string{"5a01", "5a04"}, 1, m.Config{SampleRate: 10})
+	assert.Equal(t, map[string]int{"5a01": 0, "5a04": 1}, kept)
+
+	before = sampledOut.Get()
+	for _, rate := range []int{0, 1} {
+		kept = transformAll([]string{"5b01"}, 20, m.Config{SampleRate: rate})
+		assert.Equal(t, map[string]int{"5b01": 20}, kept)
+	}
+	assert.Equal(t, int64(0), sampledOut.Get()-before)
+
+	// computed grouping keys are sampled the same way
+	msg := "sampled transformed key"
+	var keptComputed int
+	for i := 0; i < 20; i++ {
+		e := Event{Log: &Log{Message: "sampled computed key"}, config: m.Config{SampleRate: 5}}
+		keptComputed += len(e.Transform(&transform.Context{}))
+	}
+	assert.Equal(t, 4, keptComputed)
+
+	// errors are sampled by the grouping key computed from the transformed
+	// frames, here ignoring the frames excluded from grouping
+	tctx := &transform.Context{Config: transform.Config{ExcludeFromGrouping: regexp.MustCompile("^vendor/")}}
+	keys := make(map[string]bool)
+	keptComputed = 0
+	for i := 0; i < 4; i++ {
+		ex := &Exception{Message: &msg, Stacktrace: m.Stacktrace{
+			&m.StacktraceFrame{Filename: "main.go", Lineno: 10},
+			&m.StacktraceFrame{Filename: fmt.Sprintf("vendor/lib%d.go", i), Lineno: 20},
+		}}
+		events := (&Event{Exception: ex, config: m.Config{SampleRate: 4}}).Transform(tctx)
+		for _, event := range events {
+			key, _ := event.Fields.GetValue("error.grouping_key")
+			keys[key.(string)] = true
+		}
+		keptComputed += len(events)
+	}
+	assert.Equal(t, 1, keptComputed)
+	assert.Len(t, keys, 1)
+
+	// errors without any content to group by are not sampled against each other
+	keptComputed = 0
+	for i := 0; i < 10; i++ {
+		keptComputed += len((&Event{config: m.Config{SampleRate: 5}}).Transform(&transform.Context{}))
+	}
+	assert.Equal(t, 10, keptComputed)
+}
+
+func TestGroupingKeySamplerMaxKeys(t *testing.T) {
+	s := newGroupingKeySampler(2)
+	assert.True(t, s.keep("a", 2))
+	assert.False(t, s.keep("a", 2))
+	assert.True(t, s.keep("b", 2))
+	// counting starts over once counts for the maximum number of keys are held
+	assert.True(t, s.keep("c", 2))
+	assert.True(t, s.keep("a", 2))
+	assert.Len(t, s.counts, 2)
+}
@@ -0,0 +1,163 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package error
+
+import (
+	"encoding/json"
+	"strconv"
+
+	m "github.com/elastic/apm-server/model"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// Estimated sizes in bytes of the parts of a JSON encoded error document not
+// derived from the decoded values: keys, fixed values and punctuation.
+const (
+	documentBaseSize  = 150
+	exceptionBaseSize = 30
+	logBaseSize       = 30
+	frameBaseSize     = 100
+	fieldSize         = 12
+)
+
+// Size estimates the size in bytes of the JSON encoded document the event is
+// transformed into. It is computed from the decoded values without building
+// the document, so the size can be checked cheaply before queuing an event.
+// Metadata added from the transform context is not accounted for.
+func (e *Event) Size() int {
+	size := documentBaseSize
+	for _, s := range []*string{e.Id, e.TransactionId, e.TraceId, e.ParentId, e.Culprit, e.TransactionType} {
+		size += stringFieldSize(s)
+	}
+	if e.Labels != nil {
+		size += fieldSize + valueSize(common.MapStr(*e.Labels))
+	}
+	if e.Custom != nil {
+		size += fieldSize + valueSize(common.MapStr(*e.Custom))
+	}
+	for _, context := range []common.MapStr{e.User.Fields(), e.Page.Fields(), e.Http.Fields(), e.Url.Fields(), e.Service.Fields()} {
+		if context != nil {
+			size += fieldSize + valueSize(context)
+		}
+	}
+	if e.Experimental != nil {
+		size += fieldSize + valueSize(e.Experimental)
+	}
+	for _, ex := range e.exceptionChain() {
+		size += exceptionBaseSize + stacktraceSize(ex.Stacktrace)
+		for _, s := range []*string{ex.Message, ex.Module, ex.Type} {
+			size += stringFieldSize(s)
+		}
+		if ex.Code != nil {
+			size += fieldSize + valueSize(ex.Code)
+		}
+		if len(ex.Attributes) > 0 {
+			size += fieldSize + valueSize(ex.Attributes)
+		}
+	}
+	for _, log := range e.logRecords() {
+		size += logBaseSize + valueSize(log.Message) + stacktraceSize(log.Stacktrace)
+		for _, s := range []*string{log.Level, log.ParamMessage, log.LoggerName} {
+			size += stringFieldSize(s)
+		}
+	}
+	return size
+}
+
+func stacktraceSize(st m.Stacktrace) int {
+	var size int
+	for _, fr := range st {
+		size += frameBaseSize + valueSize(fr.Filename)
+		for _, s := range []*string{fr.AbsPath, fr.Module, fr.Function, fr.ContextLine} {
+			size += stringFieldSize(s)
+		}
+		if len(fr.Vars) > 0 {
+			size += fieldSize + valueSize(fr.Vars)
+		}
+		if len(fr.PreContext) > 0 {
+			size += fieldSize + valueSize(fr.PreContext)
+		}
+		if len(fr.PostContext) > 0 {
+			size += fieldSize + valueSize(fr.PostContext)
+		}
+	}
+	return size
+}
+
+func stringFieldSize(s *string) int {
+	if s == nil {
+		return 0
+	}
+	return fieldSize + valueSize(*s)
+}
+
+// valueSize estimates the size of v encoded as JSON. Characters needing to be
+// escaped are not accounted for.
+func valueSize(v interface{}) int {
+	switch v := v.(type) {
+	case nil:
+		return 4
+	case string:
+		return len(v) + 2
+	case *string:
+		if v == nil {
+			return 4
+		}
+		return len(*v) + 2
+	case bool, *bool:
+		return 5
+	case int:
+		return len(strconv.Itoa(v))
+	case *int:
+		if v == nil {
+			return 4
+		}
+		return len(strconv.Itoa(*v))
+	case int64:
+		return len(strconv.FormatInt(v, 10))
+	case json.Number:
+		return len(v)
+	case common.MapStr:
+		return mapSize(v)
+	case map[string]interface{}:
+		return mapSize(v)
+	case []string:
+		size := 2
+		for _, item := range v {
+			size += len(item) + 3
+		}
+		return size
+	case []interface{}:
+		size := 2
+		for _, item := range v {
+			size += valueSize(item) + 1
+		}
+		return size
+	default:
+		// floats and other scalar values
+		return 8
+	}
+}
+
+func mapSize(fields map[string]interface{}) int {
+	size := 2
+	for key, value := range fields {
+		size += len(key) + 4 + valueSize(value)
+	}
+	return size
+}
@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package error
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	m "github.com/elastic/apm-server/model"
+	"github.com/elastic/apm-server/transform"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestSize(t *testing.T) {
+	id, trace, parent, culprit := "0123456789abcdef", "0123456789abcdef0123456789abcdef", "abcdef0123456789", "handler in app.py"
+	msg, paramMsg, level, logger := "user 123 not found", "user %s not found", "error", "app.logger"
+	sampled, txType, txId := true, "request", "fedcba9876543210"
+	module, fct, absPath, contextLine := "app.handlers", "handle_request", "/srv/app/handlers/user.py", "raise NotFound(user_id)"
+	lineno, colno := 42, 13
+	frames := func(n int) []*m.StacktraceFrame {
+		frames := make([]*m.StacktraceFrame, n)
+		for i := range frames {
+			frames[i] = &m.StacktraceFrame{
+				Filename:    fmt.Sprintf("handlers/user%d.py", i),
+				AbsPath:     &absPath,
+				Module:      &module,
+				Function:    &fct,
+				Lineno:      lineno,
+				Colno:       &colno,
+				ContextLine: &contextLine,
+				PreContext:  []string{"def handle_request(user_id):", "    user = users.get(user_id)"},
+				PostContext: []string{"", "def other():"},
+				Vars:        common.MapStr{"user_id": "123", "retries": 3, "debug": false},
+			}
+		}
+		return frames
+	}
+	labels := m.Labels{"team": "payments", "region": "eu-west-1", "canary": true}
+	custom := m.Custom{"order": common.MapStr{"id": "o-42", "items": []interface{}{"a", "b", "c"}}}
+	method, url := "GET", "https://example.com/users/123?q=1"
+	statusCode := 404
+
+	for name, e := range map[string]*Event{
+		"minimal":    {Exception: baseException().withType("NotFound")},
+		"log":        {Log: &Log{Message: msg, ParamMessage: &paramMsg, Level: &level, LoggerName: &logger}},
+		"stacktrace": {Id: &id, Culprit: &culprit, Exception: &Exception{Message: &msg, Type: &module, Module: &module, Stacktrace: frames(50)}},
+		"chained": {
+			Exception: &Exception{Message: &msg, Type: &module, Stacktrace: frames(5), Cause: []Exception{
+				{Message: &msg, Type: &module, Stacktrace: frames(5)},
+				{Message: &msg, Type: &module, Code: "E42", Attributes: common.MapStr{"retryable": true}},
+			}},
+		},
+		"context": {
+			Id: &id, TraceId: &trace, ParentId: &parent, TransactionId: &txId,
+			TransactionSampled: &sampled, TransactionType: &txType, Culprit: &culprit,
+			Labels: &labels, Custom: &custom,
+			Http:      &m.Http{Request: &m.Req{Method: method, Headers: http.Header{"Accept": []string{"*/*"}}}, Response: &m.Resp{StatusCode: &statusCode}},
+			Url:       &m.Url{Full: &url},
+			Exception: baseException().withType("NotFound").withFrames(frames(3)),
+			Log:       &Log{Message: msg, Stacktrace: frames(2)},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			estimate := e.Size()
+			out, err := json.Marshal(e.Transform(&transform.Context{})[0].Fields)
+			require.NoError(t, err)
+			assert.InEpsilon(t, len(out), estimate, 0.15, "estimate %d, actual %d", estimate, len(out))
+		})
+	}
+}
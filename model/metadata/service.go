@@ -52,14 +52,24 @@ type Agent struct {
 }
 
 func DecodeService(input interface{}, err error) (*Service, error) {
+	return DecodeServiceAt(input, "", err)
+}
+
+// DecodeServiceAt decodes the service found at the given path within the payload,
+// e.g. "error.context.service". If path is not empty, decoding errors name the
+// offending field.
+func DecodeServiceAt(input interface{}, path string, err error) (*Service, error) {
 	if input == nil || err != nil {
 		return nil, err
 	}
 	raw, ok := input.(map[string]interface{})
 	if !ok {
+		if path != "" {
+			return nil, utility.NewFieldError(path, "object", input)
+		}
 		return nil, errors.New("invalid type for service")
 	}
-	decoder := utility.ManualDecoder{}
+	decoder := utility.ManualDecoder{Prefix: path}
 	service := Service{
 		Name:        decoder.StringPtr(raw, "name"),
 		Version:     decoder.StringPtr(raw, "version"),
@@ -34,14 +34,24 @@ type User struct {
 }
 
 func DecodeUser(input interface{}, err error) (*User, error) {
+	return DecodeUserAt(input, "", err)
+}
+
+// DecodeUserAt decodes the user found at the given path within the payload,
+// e.g. "error.context.user". If path is not empty, decoding errors name the
+// offending field.
+func DecodeUserAt(input interface{}, path string, err error) (*User, error) {
 	if input == nil || err != nil {
 		return nil, err
 	}
 	raw, ok := input.(map[string]interface{})
 	if !ok {
+		if path != "" {
+			return nil, utility.NewFieldError(path, "object", input)
+		}
 		return nil, errors.New("invalid type for user")
 	}
-	decoder := utility.ManualDecoder{}
+	decoder := utility.ManualDecoder{Prefix: path}
 	user := User{
 		UserAgent: decoder.StringPtr(raw, "user-agent"),
 		IP:        decoder.StringPtr(raw, "ip"),
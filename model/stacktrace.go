@@ -19,25 +19,49 @@ package model
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/elastic/apm-server/transform"
+	"github.com/elastic/apm-server/utility"
 	"github.com/elastic/beats/libbeat/common"
-	"github.com/elastic/beats/libbeat/logp"
 )
 
 type Stacktrace []*StacktraceFrame
 
 func DecodeStacktrace(input interface{}, err error) (*Stacktrace, error) {
+	return DecodeStacktraceAt(input, "", err)
+}
+
+// DecodeStacktraceAt decodes a stacktrace found at the given path within the
+// payload, e.g. "error.exception.stacktrace". If path is not empty, decoding
+// errors name the offending field.
+func DecodeStacktraceAt(input interface{}, path string, err error) (*Stacktrace, error) {
+	return DecodeStacktraceFunc(input, path, nil, err)
+}
+
+// DecodeStacktraceFunc decodes a stacktrace like DecodeStacktraceAt, calling
+// visit, if not nil, with every frame as soon as it is decoded.
+func DecodeStacktraceFunc(input interface{}, path string, visit func(*StacktraceFrame), err error) (*Stacktrace, error) {
 	if input == nil || err != nil {
 		return nil, err
 	}
 	raw, ok := input.([]interface{})
 	if !ok {
+		if path != "" {
+			return nil, utility.NewFieldError(path, "array", input)
+		}
 		return nil, errors.New("invalid type for stacktrace")
 	}
 	st := make(Stacktrace, len(raw))
 	for idx, fr := range raw {
-		st[idx], err = DecodeStacktraceFrame(fr, err)
+		var framePath string
+		if path != "" {
+			framePath = fmt.Sprintf("%s[%d]", path, idx)
+		}
+		st[idx], err = decodeStacktraceFrame(fr, framePath, err)
+		if visit != nil && st[idx] != nil && err == nil {
+			visit(st[idx])
+		}
 	}
 	return &st, err
 }
@@ -82,7 +106,7 @@ func (st *Stacktrace) Transform(tctx *transform.Context) []common.MapStr {
 		}
 		frames[idx] = fr.Transform(tctx)
 	}
-	logger := logp.NewLogger("stacktrace")
+	logger := tctx.Log("stacktrace")
 	for errMsg, _ := range sourcemapErrorSet {
 		if errMsg != "" {
 			logger.Warn(errMsg)
@@ -20,6 +20,7 @@ package model
 import (
 	"errors"
 	"regexp"
+	"sync"
 
 	"github.com/elastic/apm-server/model/metadata"
 	"github.com/elastic/apm-server/sourcemap"
@@ -64,14 +65,21 @@ type Original struct {
 }
 
 func DecodeStacktraceFrame(input interface{}, err error) (*StacktraceFrame, error) {
+	return decodeStacktraceFrame(input, "", err)
+}
+
+func decodeStacktraceFrame(input interface{}, path string, err error) (*StacktraceFrame, error) {
 	if input == nil || err != nil {
 		return nil, err
 	}
 	raw, ok := input.(map[string]interface{})
 	if !ok {
+		if path != "" {
+			return nil, utility.NewFieldError(path, "object", input)
+		}
 		return nil, errors.New("invalid type for stacktrace frame")
 	}
-	decoder := utility.ManualDecoder{}
+	decoder := utility.ManualDecoder{Prefix: path}
 	frame := StacktraceFrame{
 		AbsPath:      decoder.StringPtr(raw, "abs_path"),
 		Filename:     decoder.String(raw, "filename"),
@@ -105,36 +113,49 @@ func (s *StacktraceFrame) Transform(tctx *transform.Context) common.MapStr {
 	}
 	utility.Set(m, "exclude_from_grouping", s.ExcludeFromGrouping)
 
-	context := common.MapStr{}
-	utility.Set(context, "pre", s.PreContext)
-	utility.Set(context, "post", s.PostContext)
-	utility.Set(m, "context", context)
+	// the nested fields are collected in a scratch map, which is copied by
+	// utility.Set and so can be reset and reused
+	scratch := scratchMaps.Get().(common.MapStr)
+	defer scratchMaps.Put(scratch)
 
-	line := common.MapStr{}
-	utility.Set(line, "number", s.Lineno)
-	utility.Set(line, "column", s.Colno)
-	utility.Set(line, "context", s.ContextLine)
-	utility.Set(m, "line", line)
+	utility.Set(scratch, "pre", s.PreContext)
+	utility.Set(scratch, "post", s.PostContext)
+	setScratch(m, "context", scratch)
 
-	sm := common.MapStr{}
-	utility.Set(sm, "updated", s.Sourcemap.Updated)
-	utility.Set(sm, "error", s.Sourcemap.Error)
-	utility.Set(m, "sourcemap", sm)
+	utility.Set(scratch, "number", s.Lineno)
+	utility.Set(scratch, "column", s.Colno)
+	utility.Set(scratch, "context", s.ContextLine)
+	setScratch(m, "line", scratch)
 
-	orig := common.MapStr{}
-	utility.Set(orig, "library_frame", s.Original.LibraryFrame)
+	utility.Set(scratch, "updated", s.Sourcemap.Updated)
+	utility.Set(scratch, "error", s.Sourcemap.Error)
+	setScratch(m, "sourcemap", scratch)
+
+	utility.Set(scratch, "library_frame", s.Original.LibraryFrame)
 	if s.Sourcemap.Updated != nil && *(s.Sourcemap.Updated) {
-		utility.Set(orig, "filename", s.Original.Filename)
-		utility.Set(orig, "abs_path", s.Original.AbsPath)
-		utility.Set(orig, "function", s.Original.Function)
-		utility.Set(orig, "colno", s.Original.Colno)
-		utility.Set(orig, "lineno", s.Original.Lineno)
+		utility.Set(scratch, "filename", s.Original.Filename)
+		utility.Set(scratch, "abs_path", s.Original.AbsPath)
+		utility.Set(scratch, "function", s.Original.Function)
+		utility.Set(scratch, "colno", s.Original.Colno)
+		utility.Set(scratch, "lineno", s.Original.Lineno)
 	}
-	utility.Set(m, "original", orig)
+	setScratch(m, "original", scratch)
 
 	return m
 }
 
+// scratchMaps holds the maps nested frame fields are collected in.
+var scratchMaps = sync.Pool{New: func() interface{} { return common.MapStr{} }}
+
+// setScratch copies the fields collected in scratch to key of m and resets
+// scratch for reuse.
+func setScratch(m common.MapStr, key string, scratch common.MapStr) {
+	utility.Set(m, key, scratch)
+	for k := range scratch {
+		delete(scratch, k)
+	}
+}
+
 func (s *StacktraceFrame) IsLibraryFrame() bool {
 	return s.LibraryFrame != nil && *s.LibraryFrame
 }
@@ -143,6 +164,12 @@ func (s *StacktraceFrame) IsSourcemapApplied() bool {
 	return s.Sourcemap.Updated != nil && *s.Sourcemap.Updated
 }
 
+// IsSourcemapAttempted reports whether applying a sourcemap to the frame was
+// attempted, whether or not it succeeded.
+func (s *StacktraceFrame) IsSourcemapAttempted() bool {
+	return s.Original.sourcemapCopied
+}
+
 func (s *StacktraceFrame) setExcludeFromGrouping(pattern *regexp.Regexp) {
 	s.ExcludeFromGrouping = pattern.MatchString(s.Filename)
 }
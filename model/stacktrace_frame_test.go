@@ -151,9 +151,14 @@ func TestStacktraceFrameTransform(t *testing.T) {
 	}
 	tctx := transform.Context{}
 
+	// all frames are transformed before checking the outputs, as transforming
+	// a frame must not change the output of another
+	outputs := make([]common.MapStr, len(tests))
+	for idx := range tests {
+		outputs[idx] = (&tests[idx].StFrame).Transform(&tctx)
+	}
 	for idx, test := range tests {
-		output := (&test.StFrame).Transform(&tctx)
-		assert.Equal(t, test.Output, output, fmt.Sprintf("Failed at idx %v; %s", idx, test.Msg))
+		assert.Equal(t, test.Output, outputs[idx], fmt.Sprintf("Failed at idx %v; %s", idx, test.Msg))
 	}
 }
 
@@ -347,6 +352,17 @@ func TestIsSourcemapApplied(t *testing.T) {
 	assert.True(t, fr.IsSourcemapApplied())
 }
 
+func TestIsSourcemapAttempted(t *testing.T) {
+	service := &metadata.Service{Name: new(string)}
+	fr := StacktraceFrame{}
+	assert.False(t, fr.IsSourcemapAttempted())
+
+	// attempted, but failed without colno
+	fr.applySourcemap(&FakeMapper{}, service, "")
+	assert.True(t, fr.IsSourcemapAttempted())
+	assert.False(t, fr.IsSourcemapApplied())
+}
+
 func TestExcludeFromGroupingKey(t *testing.T) {
 	tests := []struct {
 		fr      StacktraceFrame
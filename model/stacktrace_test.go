@@ -23,6 +23,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/apm-server/model/metadata"
 	"github.com/elastic/apm-server/transform"
@@ -59,6 +60,43 @@ func TestStacktraceDecode(t *testing.T) {
 	}
 }
 
+func TestStacktraceDecodeAt(t *testing.T) {
+	for _, test := range []struct {
+		input interface{}
+		err   string
+	}{
+		{input: "", err: "error.log.stacktrace: expected array, got string"},
+		{input: []interface{}{"foo"}, err: "error.log.stacktrace[0]: expected object, got string"},
+		{
+			input: []interface{}{map[string]interface{}{"filename": "file", "lineno": 1.0, "colno": "1"}},
+			err:   "error.log.stacktrace[0].colno: expected integer, got string",
+		},
+	} {
+		_, err := DecodeStacktraceAt(test.input, "error.log.stacktrace", nil)
+		assert.EqualError(t, err, test.err)
+	}
+}
+
+func TestStacktraceDecodeFunc(t *testing.T) {
+	input := []interface{}{
+		map[string]interface{}{"filename": "file0", "lineno": 1.0},
+		map[string]interface{}{"filename": "file1", "lineno": 2.0},
+		map[string]interface{}{"filename": "file2", "lineno": "3"},
+	}
+	var visited []string
+	visit := func(fr *StacktraceFrame) { visited = append(visited, fr.Filename) }
+
+	st, err := DecodeStacktraceFunc(input[:2], "error.log.stacktrace", visit, nil)
+	require.NoError(t, err)
+	assert.Len(t, *st, 2)
+	assert.Equal(t, []string{"file0", "file1"}, visited)
+
+	visited = nil
+	_, err = DecodeStacktraceFunc(input, "error.log.stacktrace", visit, nil)
+	assert.EqualError(t, err, "error.log.stacktrace[2].lineno: expected integer, got string")
+	assert.Equal(t, []string{"file0", "file1"}, visited)
+}
+
 func TestStacktraceTransform(t *testing.T) {
 	colno := 1
 	fct := "original function"
@@ -29,6 +29,7 @@ import (
 
 	"golang.org/x/time/rate"
 
+	"github.com/elastic/apm-server/model"
 	"github.com/elastic/apm-server/publish"
 	"github.com/elastic/apm-server/tests/loader"
 )
@@ -76,3 +77,31 @@ func BenchmarkStreamProcessor(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkStreamProcessorSkipErrorSchemaValidation(b *testing.B) {
+	report := func(ctx context.Context, p publish.PendingReq) error {
+		return nil
+	}
+	data, err := loader.LoadDataAsBytes("../testdata/intake-v2/errors.ndjson")
+	if err != nil {
+		b.Fatal(err)
+	}
+	for name, sp := range map[string]*Processor{
+		"Validated": {MaxEventSize: 300 * 1024},
+		"Skipped":   {MaxEventSize: 300 * 1024, Mconfig: model.Config{SkipErrorSchemaValidation: true}},
+	} {
+		sp := sp
+		b.Run(name, func(b *testing.B) {
+			r := bytes.NewReader(data)
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				r.Reset(data)
+				b.StartTimer()
+				sp.HandleStream(context.Background(), nil, map[string]interface{}{}, r, report)
+			}
+		})
+	}
+}
@@ -21,6 +21,8 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"github.com/elastic/apm-server/model/error/generated/schema"
 	"github.com/elastic/apm-server/processor/stream"
 	"github.com/elastic/apm-server/tests"
@@ -42,6 +44,7 @@ func errorProcSetup() *tests.ProcessorSetup {
 func errorPayloadAttrsNotInFields() *tests.Set {
 	return tests.NewSet(
 		tests.Group("error.exception.attributes"),
+		tests.Group("error.exception.cause"),
 		tests.Group("error.exception.stacktrace"),
 		tests.Group("error.log.stacktrace"),
 		tests.Group("context"),
@@ -54,6 +57,28 @@ func errorFieldsNotInPayloadAttrs() *tests.Set {
 	return tests.NewSet(
 		"view errors", "error id icon",
 		"host.ip",
+		"error.log.message_template",
+		"error.exception.code_category",
+		"error.exception.code_numeric",
+		"error.grouping_key_version",
+		"error.grouping_name",
+		"error.original_culprit",
+		"error.exception.stacktrace_frames_omitted",
+		"error.exception.cause_count",
+		"error.exception.chain_position",
+		"error.exception.message_hash",
+		"error.exception.thread_name",
+		"error.exception.thread_id",
+		"error.exception.process_name",
+		"error.exception.process_id",
+		"error.exception.type_hierarchy",
+		"error.log.stacktrace_frames_omitted",
+		"error.log.severity",
+		"error.exception.stacktrace_sourcemap_applied",
+		"error.log.stacktrace_sourcemap_applied",
+		"error.exception.stacktrace_sourcemap_error_count",
+		"error.log.stacktrace_sourcemap_error_count",
+		"event.ingested",
 		tests.Group("observer"),
 		tests.Group("user"),
 		tests.Group("client"),
@@ -116,7 +141,10 @@ func errorCondRequiredKeys() map[string]tests.Condition {
 
 func errorKeywordExceptionKeys() *tests.Set {
 	return tests.NewSet(
-		"processor.event", "processor.name", "error.grouping_key",
+		"processor.event", "processor.name", "error.grouping_key", "error.grouping_key_source",
+		"error.log.message_template", "error.original_culprit", "error.exception.type_hierarchy",
+		"error.exception.thread_name", "error.exception.thread_id", "error.exception.process_name", "error.type",
+		"error.grouping_name", "error.exception.message_hash", "error.exception.code_category",
 		"context.tags",
 		"view errors", "error id icon",
 		tests.Group("url"),
@@ -142,7 +170,21 @@ func TestErrorPayloadAttrsMatchFields(t *testing.T) {
 func TestErrorPayloadAttrsMatchJsonSchema(t *testing.T) {
 	errorProcSetup().PayloadAttrsMatchJsonSchema(t,
 		errorPayloadAttrsNotInJsonSchema(),
-		tests.NewSet("error.context.user.email", "error.context.experimental"))
+		tests.NewSet(
+			"error.context.user.email",
+			"error.context.experimental",
+			"error.exception.code.value",
+			"error.exception.code.category",
+			"error.exception.cause.attributes",
+			"error.exception.cause.cause",
+			"error.exception.cause.handled",
+			"error.exception.cause.module",
+			tests.Group("error.exception.cause.code"),
+			tests.Group("error.exception.cause.stacktrace"),
+			tests.Group("error.parent"),
+			tests.Group("error.exception.stacktrace.frames"),
+			tests.Group("error.log.stacktrace.frames"),
+		))
 }
 
 func TestErrorAttrsPresenceInError(t *testing.T) {
@@ -172,14 +214,18 @@ func TestPayloadDataForError(t *testing.T) {
 		[]tests.SchemaTestData{
 			{Key: "error",
 				Invalid: []tests.Invalid{{Msg: `/type`, Values: val{false}}}},
-			{Key: "error.exception.code", Valid: val{"success", ""},
+			{Key: "error.exception.code", Valid: val{"success", "", obj{"value": "success", "category": "http"}},
 				Invalid: []tests.Invalid{{Msg: `exception/properties/code/type`, Values: val{false}}}},
-			{Key: "error.exception.attributes", Valid: val{map[string]interface{}{}},
-				Invalid: []tests.Invalid{{Msg: `exception/properties/attributes/type`, Values: val{123}}}},
+			{Key: "error.exception.attributes", Valid: val{map[string]interface{}{}}},
+			{Key: "error.exception.cause",
+				Valid: val{[]interface{}{obj{"message": "connection refused", "cause": []interface{}{obj{"type": "IOError"}}}}},
+				Invalid: []tests.Invalid{
+					{Msg: `anyof/0/properties/message/type`, Values: val{[]interface{}{obj{"message": 123}}}},
+					{Msg: `anyof/1/required`, Values: val{[]interface{}{obj{"module": "net"}}}}}},
 			{Key: "error.timestamp",
-				Valid: val{json.Number("1496170422281000")},
+				Valid: val{json.Number("1496170422281000"), "2017-05-30T18:53:42.281Z"},
 				Invalid: []tests.Invalid{
-					{Msg: `timestamp/type`, Values: val{"1496170422281000"}}}},
+					{Msg: `timestamp/type`, Values: val{false}}}},
 			{Key: "error.log.stacktrace.post_context",
 				Valid: val{[]interface{}{}, []interface{}{"context"}},
 				Invalid: []tests.Invalid{
@@ -228,9 +274,37 @@ func TestPayloadDataForError(t *testing.T) {
 					{Msg: `context/properties/tags/type`, Values: val{"tags"}},
 					{Msg: `context/properties/tags/patternproperties`, Values: val{obj{"invalid": tests.Str1025}, obj{tests.Str1024: obj{}}}},
 					{Msg: `context/properties/tags/additionalproperties`, Values: val{obj{"invali*d": "hello"}, obj{"invali\"d": "hello"}, obj{"invali.d": "hello"}}}}},
+			{Key: "error.parent", Valid: val{obj{}, obj{"id": nil}},
+				Invalid: []tests.Invalid{{Msg: `properties/parent/type`, Values: val{"abc123", 123}}}},
 			{Key: "error.context.user.id", Valid: val{123, tests.Str1024Special},
 				Invalid: []tests.Invalid{
 					{Msg: `context/properties/user/properties/id/type`, Values: val{obj{}}},
 					{Msg: `context/properties/user/properties/id/maxlength`, Values: val{tests.Str1025}}}},
 		})
 }
+
+func TestErrorNestedParentId(t *testing.T) {
+	proc := errorProcSetup().Proc
+	event := func(fields obj) interface{} {
+		e := obj{"id": "abc123", "log": obj{"message": "log message"}}
+		for k, v := range fields {
+			e[k] = v
+		}
+		return val{obj{"error": e}}
+	}
+
+	for name, fields := range map[string]obj{
+		"flat":   {"trace_id": "abc123", "parent_id": "abc123"},
+		"nested": {"trace_id": "abc123", "parent": obj{"id": "abc123"}},
+		"both":   {"trace_id": "abc123", "parent_id": "abc123", "parent": obj{"id": "abc123"}},
+	} {
+		assert.NoError(t, proc.Validate(event(fields)), name)
+	}
+	for name, fields := range map[string]obj{
+		"missingParent":      {"trace_id": "abc123", "parent": obj{}},
+		"missingTrace":       {"parent": obj{"id": "abc123"}},
+		"missingNestedTrace": {"transaction_id": "abc123", "parent": obj{"id": "abc123"}},
+	} {
+		assert.Error(t, proc.Validate(event(fields)), name)
+	}
+}
@@ -168,12 +168,13 @@ func (p *Processor) readMetadata(reqMeta map[string]interface{}, reader StreamRe
 func (p *Processor) HandleRawModel(rawModel map[string]interface{}) (transform.Transformable, error) {
 	for _, model := range models {
 		if entry, ok := rawModel[model.key]; ok {
-			err := validation.Validate(entry, model.schema)
-			if err != nil {
-				return nil, err
+			if !p.skipValidation(model.key) {
+				if err := validation.Validate(entry, model.schema); err != nil {
+					return nil, err
+				}
 			}
 
-			tr, err := model.modelDecoder(entry, p.Mconfig, err)
+			tr, err := model.modelDecoder(entry, p.Mconfig, nil)
 			if err != nil {
 				return nil, err
 			}
@@ -183,6 +184,12 @@ func (p *Processor) HandleRawModel(rawModel map[string]interface{}) (transform.T
 	return nil, ErrUnrecognizedObject
 }
 
+// skipValidation reports whether objects of the given model are decoded
+// without validating them against the model's JSON schema.
+func (p *Processor) skipValidation(key string) bool {
+	return key == "error" && p.Mconfig.SkipErrorSchemaValidation
+}
+
 // readBatch will read up to `batchSize` objects from the ndjson stream
 // it returns a slice of eventables and a bool that indicates if there might be more to read.
 func (p *Processor) readBatch(ctx context.Context, rl *rate.Limiter, batchSize int, reader StreamReader, response *Result) ([]transform.Transformable, bool) {
@@ -268,6 +275,7 @@ func (p *Processor) HandleStream(ctx context.Context, rl *rate.Limiter, meta map
 		RequestTime: utility.RequestTime(ctx),
 		Config:      p.Tconfig,
 		Metadata:    *metadata,
+		LogFields:   utility.RequestLogFields(ctx),
 	}
 
 	sp, ctx := apm.StartSpan(ctx, "Stream", "Reporter")
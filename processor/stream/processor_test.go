@@ -31,11 +31,16 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/time/rate"
 
+	"github.com/elastic/apm-server/model"
+	er "github.com/elastic/apm-server/model/error"
 	"github.com/elastic/apm-server/publish"
 	"github.com/elastic/apm-server/tests"
 	"github.com/elastic/apm-server/tests/loader"
+	"github.com/elastic/apm-server/transform"
 	"github.com/elastic/apm-server/utility"
 	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/monitoring"
 )
 
 func validMetadata() string {
@@ -218,3 +223,147 @@ func TestRateLimiting(t *testing.T) {
 		assertApproveResult(t, actualResult, test.name)
 	}
 }
+
+func TestHandleRawModelSkipErrorSchemaValidation(t *testing.T) {
+	type obj = map[string]interface{}
+	validating := &Processor{}
+	skipping := &Processor{Mconfig: model.Config{SkipErrorSchemaValidation: true}}
+
+	// decodable, but missing the id required by the schema
+	rawModel := obj{"error": obj{"log": obj{"message": "log message"}}}
+	_, err := validating.HandleRawModel(rawModel)
+	assert.Error(t, err)
+	_, err = skipping.HandleRawModel(rawModel)
+	assert.NoError(t, err)
+
+	// malformed errors still fail to decode
+	for name, raw := range map[string]interface{}{
+		"noObject":          "error",
+		"id":                obj{"id": 123, "log": obj{"message": "log message"}},
+		"exceptionMessage":  obj{"id": "abc", "exception": obj{"message": 123}},
+		"logStacktrace":     obj{"id": "abc", "log": obj{"message": "log message", "stacktrace": "frames"}},
+		"frame":             obj{"id": "abc", "log": obj{"message": "log message", "stacktrace": []interface{}{obj{"lineno": "1"}}}},
+		"timestamp":         obj{"id": "abc", "timestamp": "yesterday", "log": obj{"message": "log message"}},
+		"transactionSample": obj{"id": "abc", "transaction": obj{"sampled": "yes"}, "log": obj{"message": "log message"}},
+	} {
+		_, err := skipping.HandleRawModel(obj{"error": raw})
+		assert.Error(t, err, name)
+	}
+
+	// other models are validated regardless
+	_, err = skipping.HandleRawModel(obj{"transaction": obj{}})
+	assert.Error(t, err)
+}
+
+func TestHandleRawModelErrorTimestamps(t *testing.T) {
+	type obj = map[string]interface{}
+	rawError := func(timestamp interface{}) obj {
+		return obj{"error": obj{"id": "abc", "timestamp": timestamp, "log": obj{"message": "log message"}}}
+	}
+
+	for name, test := range map[string]struct {
+		config    model.Config
+		timestamp interface{}
+		expected  time.Time
+	}{
+		"epochMicros": {
+			timestamp: json.Number("1496170422281000"),
+			expected:  time.Date(2017, 5, 30, 18, 53, 42, 281000000, time.UTC),
+		},
+		"rfc3339": {
+			timestamp: "2017-05-30T18:53:42.281Z",
+			expected:  time.Date(2017, 5, 30, 18, 53, 42, 281000000, time.UTC),
+		},
+		"epochSeconds": {
+			config:    model.Config{TimestampDetectSeconds: true},
+			timestamp: json.Number("1597000000.123456"),
+			expected:  time.Date(2020, 8, 9, 19, 6, 40, 123456000, time.UTC),
+		},
+		"malformedFallback": {
+			config:    model.Config{TimestampFallback: true},
+			timestamp: "yesterday",
+		},
+		"floatFallback": {
+			config:    model.Config{TimestampFallback: true},
+			timestamp: json.Number("1597000000.123456"),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			p := &Processor{Mconfig: test.config}
+			tr, err := p.HandleRawModel(rawError(test.timestamp))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, tr.(*er.Event).Timestamp)
+		})
+	}
+
+	// without the options, malformed timestamps pass the schema but fail to decode
+	p := &Processor{}
+	for _, timestamp := range []interface{}{"yesterday", json.Number("1597000000.123456")} {
+		_, err := p.HandleRawModel(rawError(timestamp))
+		assert.Error(t, err, timestamp)
+	}
+	_, err := p.HandleRawModel(rawError(false))
+	assert.Error(t, err)
+}
+
+func TestHandleRawModelErrorObjectCode(t *testing.T) {
+	type obj = map[string]interface{}
+	p := &Processor{}
+	tr, err := p.HandleRawModel(obj{"error": obj{"id": "abc", "exception": obj{
+		"message": "exception message", "code": obj{"value": json.Number("500"), "category": "http"}}}})
+	require.NoError(t, err)
+
+	events := tr.Transform(&transform.Context{})
+	require.Len(t, events, 1)
+	exception := events[0].Fields["error"].(common.MapStr)["exception"].([]common.MapStr)[0]
+	assert.Equal(t, "500", exception["code"])
+	assert.Equal(t, "http", exception["code_category"])
+
+	// structured codes need a value
+	_, err = p.HandleRawModel(obj{"error": obj{"id": "abc", "exception": obj{
+		"message": "exception message", "code": obj{"category": "http"}}}})
+	assert.Error(t, err)
+}
+
+func TestHandleRawModelErrorLenientBool(t *testing.T) {
+	type obj = map[string]interface{}
+	rawError := func(handled, sampled interface{}) obj {
+		return obj{"error": obj{
+			"id":          "abc",
+			"exception":   obj{"message": "exception message", "handled": handled},
+			"transaction": obj{"sampled": sampled},
+		}}
+	}
+
+	lenient := &Processor{Mconfig: model.Config{LenientBoolDecoding: true}}
+	tr, err := lenient.HandleRawModel(rawError("0", "true"))
+	require.NoError(t, err)
+	event := tr.(*er.Event)
+	require.NotNil(t, event.Exception.Handled)
+	assert.False(t, *event.Exception.Handled)
+	require.NotNil(t, event.TransactionSampled)
+	assert.True(t, *event.TransactionSampled)
+
+	// other strings are rejected by the schema, and boolean strings fail to
+	// decode unless configured
+	_, err = lenient.HandleRawModel(rawError("yes", true))
+	assert.Error(t, err)
+	_, err = (&Processor{}).HandleRawModel(rawError("0", "true"))
+	assert.Error(t, err)
+}
+
+func TestHandleRawModelErrorDropInvalidAttributes(t *testing.T) {
+	type obj = map[string]interface{}
+	rawError := obj{"error": obj{"id": "abc", "exception": obj{"message": "exception message", "attributes": 123}}}
+
+	_, err := (&Processor{}).HandleRawModel(rawError)
+	assert.Error(t, err)
+
+	dropped := er.Metrics.Get("attributes_dropped").(*monitoring.Int)
+	before := dropped.Get()
+	dropping := &Processor{Mconfig: model.Config{DropInvalidExceptionAttributes: true}}
+	tr, err := dropping.HandleRawModel(rawError)
+	require.NoError(t, err)
+	assert.Nil(t, tr.(*er.Event).Exception.Attributes)
+	assert.Equal(t, before+1, dropped.Get())
+}
@@ -22,6 +22,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"regexp"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -386,19 +387,85 @@ type Schema struct {
 	OneOf                []*Schema
 	AnyOf                []*Schema
 	MaxLength            int
+	Ref                  string `json:"$ref"`
 }
 type Mapping struct {
 	from string
 	to   string
 }
 
+// ParseSchema parses the given JSON schema, resolving local references.
+// Recursive references are resolved once, so that the properties of
+// recursive definitions are only expanded a single time.
 func ParseSchema(s string) (*Schema, error) {
 	decoder := json.NewDecoder(bytes.NewBufferString(s))
 	var schema Schema
-	err := decoder.Decode(&schema)
+	if err := decoder.Decode(&schema); err != nil {
+		return nil, err
+	}
+	var root interface{}
+	if err := json.Unmarshal([]byte(s), &root); err != nil {
+		return nil, err
+	}
+	err := resolveRefs(&schema, root, map[string]bool{})
 	return &schema, err
 }
 
+func resolveRefs(s *Schema, root interface{}, resolving map[string]bool) error {
+	if strings.HasPrefix(s.Ref, "#/") && !resolving[s.Ref] {
+		ref := s.Ref
+		fragment := root
+		for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			switch f := fragment.(type) {
+			case map[string]interface{}:
+				fragment = f[token]
+			case []interface{}:
+				idx, err := strconv.Atoi(token)
+				if err != nil || idx < 0 || idx >= len(f) {
+					return fmt.Errorf("invalid reference %s", ref)
+				}
+				fragment = f[idx]
+			default:
+				return fmt.Errorf("invalid reference %s", ref)
+			}
+		}
+		encoded, err := json.Marshal(fragment)
+		if err != nil {
+			return err
+		}
+		var resolved Schema
+		if err := json.Unmarshal(encoded, &resolved); err != nil {
+			return err
+		}
+		resolving[ref] = true
+		defer delete(resolving, ref)
+		if err := resolveRefs(&resolved, root, resolving); err != nil {
+			return err
+		}
+		*s = resolved
+		return nil
+	}
+
+	for _, p := range s.Properties {
+		if err := resolveRefs(p, root, resolving); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		if err := resolveRefs(s.Items, root, resolving); err != nil {
+			return err
+		}
+	}
+	for _, schemas := range [][]*Schema{s.AllOf, s.OneOf, s.AnyOf} {
+		for _, e := range schemas {
+			if err := resolveRefs(e, root, resolving); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func FlattenSchemaNames(s *Schema, prefix string, filter func(*Schema) bool, flattened *Set) {
 	if len(s.Properties) > 0 {
 		for k, v := range s.Properties {
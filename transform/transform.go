@@ -24,6 +24,7 @@ import (
 	"github.com/elastic/apm-server/model/metadata"
 	"github.com/elastic/apm-server/sourcemap"
 	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/logp"
 )
 
 type Transformable interface {
@@ -34,6 +35,21 @@ type Context struct {
 	RequestTime time.Time
 	Config      Config
 	Metadata    metadata.Metadata
+
+	// LogFields are the key value pairs identifying the request the events
+	// belong to in log entries, e.g. its id.
+	LogFields []interface{}
+}
+
+// Log returns the logger for recording anomalies found when transforming
+// events, named with the given selector. Entries carry the request's log
+// fields if set, so they can be correlated with the request.
+func (c *Context) Log(selector string) *logp.Logger {
+	logger := logp.NewLogger(selector)
+	if c == nil || len(c.LogFields) == 0 {
+		return logger
+	}
+	return logger.With(c.LogFields...)
 }
 
 type Config struct {
@@ -20,7 +20,9 @@ package utility
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/elastic/beats/libbeat/common"
@@ -28,12 +30,72 @@ import (
 
 type ManualDecoder struct {
 	Err error
+
+	// Prefix is the path of the decoded object within the payload, e.g.
+	// "error.exception". If set, decoding failures are reported as
+	// FieldError naming the offending field instead of FetchErr.
+	Prefix string
 }
 
 var (
 	FetchErr = errors.New("Error fetching field")
 )
 
+// FieldError describes a field which could not be decoded.
+type FieldError struct {
+	Path     string
+	Expected string
+	Actual   string
+}
+
+// NewFieldError returns a FieldError for the field at path, naming the JSON
+// type of val as the actual type.
+func NewFieldError(path, expected string, val interface{}) *FieldError {
+	return &FieldError{Path: path, Expected: expected, Actual: jsonType(val)}
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: expected %s, got %s", e.Path, e.Expected, e.Actual)
+}
+
+// FieldPath returns the path of key within the object found at path. If path
+// is empty, so is the returned path, keeping decoders without a path
+// reporting FetchErr.
+func FieldPath(path, key string) string {
+	if path == "" {
+		return ""
+	}
+	return path + "." + key
+}
+
+func jsonType(val interface{}) string {
+	switch val.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case json.Number, float64, float32,
+		int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}, common.MapStr:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", val)
+	}
+}
+
+func (d *ManualDecoder) fetchErr(val interface{}, expected, key string, keys ...string) error {
+	if d.Prefix == "" {
+		return FetchErr
+	}
+	path := append([]string{d.Prefix}, keys...)
+	return NewFieldError(strings.Join(append(path, key), "."), expected, val)
+}
+
 func (d *ManualDecoder) Float64(base map[string]interface{}, key string, keys ...string) float64 {
 	val := getDeep(base, keys...)[key]
 	if valFloat, ok := val.(float64); ok {
@@ -46,7 +108,7 @@ func (d *ManualDecoder) Float64(base map[string]interface{}, key string, keys ..
 		}
 	}
 
-	d.Err = FetchErr
+	d.Err = d.fetchErr(val, "number", key, keys...)
 	return 0.0
 }
 
@@ -64,7 +126,7 @@ func (d *ManualDecoder) Float64Ptr(base map[string]interface{}, key string, keys
 		}
 	}
 
-	d.Err = FetchErr
+	d.Err = d.fetchErr(val, "number", key, keys...)
 	return nil
 }
 
@@ -90,7 +152,7 @@ func (d *ManualDecoder) IntPtr(base map[string]interface{}, key string, keys ...
 			return &valInt
 		}
 	}
-	d.Err = FetchErr
+	d.Err = d.fetchErr(val, "integer", key, keys...)
 	return nil
 }
 
@@ -111,7 +173,7 @@ func (d *ManualDecoder) Int64Ptr(base map[string]interface{}, key string, keys .
 			return &valInt
 		}
 	}
-	d.Err = FetchErr
+	d.Err = d.fetchErr(val, "integer", key, keys...)
 	return nil
 }
 
@@ -119,7 +181,7 @@ func (d *ManualDecoder) Int(base map[string]interface{}, key string, keys ...str
 	if val := d.IntPtr(base, key, keys...); val != nil {
 		return *val
 	}
-	d.Err = FetchErr
+	d.Err = d.fetchErr(getDeep(base, keys...)[key], "integer", key, keys...)
 	return 0
 }
 
@@ -130,7 +192,7 @@ func (d *ManualDecoder) StringPtr(base map[string]interface{}, key string, keys
 	} else if valStr, ok := val.(string); ok {
 		return &valStr
 	}
-	d.Err = FetchErr
+	d.Err = d.fetchErr(val, "string", key, keys...)
 	return nil
 }
 
@@ -138,7 +200,7 @@ func (d *ManualDecoder) String(base map[string]interface{}, key string, keys ...
 	if val := d.StringPtr(base, key, keys...); val != nil {
 		return *val
 	}
-	d.Err = FetchErr
+	d.Err = d.fetchErr(getDeep(base, keys...)[key], "string", key, keys...)
 	return ""
 }
 
@@ -154,7 +216,7 @@ func (d *ManualDecoder) StringArr(base map[string]interface{}, key string, keys
 			if valStr, ok := v.(string); ok {
 				strArr[idx] = valStr
 			} else {
-				d.Err = FetchErr
+				d.Err = d.fetchErr(arr, "array of strings", key, keys...)
 				return nil
 			}
 		}
@@ -163,7 +225,7 @@ func (d *ManualDecoder) StringArr(base map[string]interface{}, key string, keys
 	if strArr, ok := arr.([]string); ok {
 		return strArr
 	}
-	d.Err = FetchErr
+	d.Err = d.fetchErr(arr, "array of strings", key, keys...)
 	return nil
 }
 
@@ -178,7 +240,7 @@ func (d *ManualDecoder) InterfaceArr(base map[string]interface{}, key string, ke
 	} else if valArr, ok := val.([]interface{}); ok {
 		return valArr
 	}
-	d.Err = FetchErr
+	d.Err = d.fetchErr(val, "array", key, keys...)
 	return nil
 }
 
@@ -189,7 +251,7 @@ func (d *ManualDecoder) BoolPtr(base map[string]interface{}, key string, keys ..
 	} else if valBool, ok := val.(bool); ok {
 		return &valBool
 	}
-	d.Err = FetchErr
+	d.Err = d.fetchErr(val, "boolean", key, keys...)
 	return nil
 }
 
@@ -200,7 +262,7 @@ func (d *ManualDecoder) MapStr(base map[string]interface{}, key string, keys ...
 	} else if valMapStr, ok := val.(map[string]interface{}); ok {
 		return valMapStr
 	}
-	d.Err = FetchErr
+	d.Err = d.fetchErr(val, "object", key, keys...)
 	return nil
 }
 
@@ -214,7 +276,7 @@ func (d *ManualDecoder) TimeRFC3339(base map[string]interface{}, key string, key
 			return valTime
 		}
 	}
-	d.Err = FetchErr
+	d.Err = d.fetchErr(val, "RFC3339 timestamp", key, keys...)
 	return time.Time{}
 }
 
@@ -231,7 +293,7 @@ func (d *ManualDecoder) TimeEpochMicro(base map[string]interface{}, key string,
 			return time.Unix(sec, microsec*1000).UTC()
 		}
 	}
-	d.Err = FetchErr
+	d.Err = d.fetchErr(val, "integer", key, keys...)
 	return time.Time{}
 }
 
@@ -246,7 +308,7 @@ func (d *ManualDecoder) Headers(base map[string]interface{}) http.Header {
 			httpHeader.Add(key, v)
 			continue
 		}
-		vals := d.StringArr(h, key)
+		vals := d.StringArr(base, key, "headers")
 		if d.Err != nil {
 			return nil
 		}
@@ -255,3 +255,39 @@ func TestTimeRFC3339(t *testing.T) {
 		assert.Equal(t, decoder.Err, test.err)
 	}
 }
+
+func TestFieldErrors(t *testing.T) {
+	for _, test := range []struct {
+		decode func(*ManualDecoder)
+		err    string
+	}{
+		{
+			decode: func(d *ManualDecoder) { d.StringPtr(decoderBase, "true") },
+			err:    "error.true: expected string, got boolean",
+		},
+		{
+			decode: func(d *ManualDecoder) { d.IntPtr(decoderBase, "fl64", "a", "b") },
+			err:    "error.a.b.fl64: expected integer, got number",
+		},
+		{
+			decode: func(d *ManualDecoder) { d.String(decoderBase, "missing") },
+			err:    "error.missing: expected string, got null",
+		},
+		{
+			decode: func(d *ManualDecoder) { d.MapStr(decoderBase, "strArr") },
+			err:    "error.strArr: expected object, got array",
+		},
+		{
+			decode: func(d *ManualDecoder) { d.InterfaceArr(decoderBase, "a") },
+			err:    "error.a: expected array, got object",
+		},
+		{
+			decode: func(d *ManualDecoder) { d.StringPtr(map[string]interface{}{"int": 1}, "int") },
+			err:    "error.int: expected string, got number",
+		},
+	} {
+		decoder := ManualDecoder{Prefix: "error"}
+		test.decode(&decoder)
+		assert.EqualError(t, decoder.Err, test.err)
+	}
+}
@@ -0,0 +1,53 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package utility
+
+import (
+	"context"
+
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+const (
+	requestLoggerContextKey    = contextKey("requestLogger")
+	requestLogFieldsContextKey = contextKey("requestLogFields")
+)
+
+// ContextWithRequestLogger returns a context holding the logger for the
+// request it belongs to.
+func ContextWithRequestLogger(ctx context.Context, logger *logp.Logger) context.Context {
+	return context.WithValue(ctx, requestLoggerContextKey, logger)
+}
+
+// RequestLogger returns the request logger held by the context, or nil.
+func RequestLogger(ctx context.Context) *logp.Logger {
+	logger, _ := ctx.Value(requestLoggerContextKey).(*logp.Logger)
+	return logger
+}
+
+// ContextWithRequestLogFields returns a context holding the key value pairs
+// identifying the request it belongs to in log entries.
+func ContextWithRequestLogFields(ctx context.Context, fields []interface{}) context.Context {
+	return context.WithValue(ctx, requestLogFieldsContextKey, fields)
+}
+
+// RequestLogFields returns the request log fields held by the context, or nil.
+func RequestLogFields(ctx context.Context) []interface{} {
+	fields, _ := ctx.Value(requestLogFieldsContextKey).([]interface{})
+	return fields
+}